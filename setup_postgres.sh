@@ -22,7 +22,7 @@ cleanup_existing_container() {
 # Function to start Postgres container
 start_postgres() {
     cleanup_existing_container
-    if ! docker run --name $CONTAINER_NAME -e POSTGRES_PASSWORD=postgres -d -p $POSTGRES_PORT:5432 postgres:14; then
+    if ! docker run --name $CONTAINER_NAME -e POSTGRES_PASSWORD=postgres -d -p $POSTGRES_PORT:5432 pgvector/pgvector:pg14; then
         echo "Error starting postgres container" >&2
         exit 1
     fi
@@ -37,7 +37,7 @@ start_postgres() {
 
 # Attempt to pull the postgres Docker image
 echo "Pulling postgres image..."
-if ! docker pull postgres:14; then
+if ! docker pull pgvector/pgvector:pg14; then
     echo "Failed to pull postgres image" >&2
     exit 1
 fi
@@ -0,0 +1,350 @@
+package chunker
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"accretional.com/semantifly/diversify"
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+const (
+	DefaultMaxTokens     = 256
+	DefaultOverlapTokens = 32
+)
+
+// Tokens are runs of letters, digits and underscores, or single punctuation characters. This approximates
+// the tokenizers of embedding models closely enough to keep chunks within their input limits.
+var tokenRegex = regexp.MustCompile(`[\p{L}\p{N}_]+|[^\s\p{L}\p{N}_]`)
+
+var paragraphBreakRegex = regexp.MustCompile(`\n[ \t]*\n`)
+
+// Input is what a chunker splits: the content of an entry, and its sections if its DataType has an extractor.
+type Input struct {
+	URI      string
+	Content  []byte
+	Sections []*pb.Section
+}
+
+type ChunkerInfo struct {
+	Description string
+	Chunk       func(in *Input, maxTokens int, overlapTokens int) []*pb.Chunk
+}
+
+var chunkerDict = map[string]ChunkerInfo{
+	"tokens": {
+		Description: "Fixed windows of max_tokens tokens, consecutive windows sharing overlap_tokens tokens",
+		Chunk:       chunkTokens,
+	},
+	"paragraph": {
+		Description: "Paragraphs separated by blank lines, merged up to max_tokens tokens",
+		Chunk:       chunkParagraphs,
+	},
+	"section": {
+		Description: "One chunk per extracted section, eg. markdown heading or protobuf message",
+		Chunk:       chunkSections,
+	},
+	"symbol": {
+		Description: "One chunk per top-level code symbol, eg. function, class or type, with its leading comments",
+		Chunk:       chunkSymbols,
+	},
+}
+
+// Names returns the names of the available chunkers, sorted.
+func Names() []string {
+	names := make([]string, 0, len(chunkerDict))
+	for name := range chunkerDict {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Description describes how the named chunker splits content.
+func Description(name string) string {
+	return chunkerDict[name].Description
+}
+
+// Resolve fills in the defaults of a chunking config for an entry: the chunker is chosen from the entry's
+// sections and file extension when not set, and token limits default to DefaultMaxTokens and DefaultOverlapTokens.
+// The overlap is capped to half of the maximum.
+func Resolve(config *pb.ChunkingConfig, uri string, hasSections bool) *pb.ChunkingConfig {
+	resolved := &pb.ChunkingConfig{
+		Chunker:       config.GetChunker(),
+		MaxTokens:     config.GetMaxTokens(),
+		OverlapTokens: config.GetOverlapTokens(),
+	}
+
+	if resolved.Chunker == "" {
+		switch {
+		case hasSections:
+			resolved.Chunker = "section"
+		case IsCode(uri):
+			resolved.Chunker = "symbol"
+		default:
+			resolved.Chunker = "paragraph"
+		}
+	}
+	if resolved.MaxTokens <= 0 {
+		resolved.MaxTokens = DefaultMaxTokens
+	}
+	// a negative overlap disables it, since zero means unset
+	if resolved.OverlapTokens == 0 {
+		resolved.OverlapTokens = DefaultOverlapTokens
+	} else if resolved.OverlapTokens < 0 {
+		resolved.OverlapTokens = 0
+	}
+	if resolved.OverlapTokens >= resolved.MaxTokens {
+		resolved.OverlapTokens = resolved.MaxTokens / 2
+	}
+
+	return resolved
+}
+
+// Chunk splits the content of an entry into chunks according to the chunking config, with defaults as
+// described by Resolve. Chunks are numbered in order and record the name of the entry they belong to,
+// and the digest and SimHash fingerprint of their text.
+func Chunk(entryName string, in *Input, config *pb.ChunkingConfig) ([]*pb.Chunk, error) {
+	resolved := Resolve(config, in.URI, len(in.Sections) > 0)
+
+	info, ok := chunkerDict[resolved.Chunker]
+	if !ok {
+		return nil, fmt.Errorf("unknown chunker %s, expected one of %s", resolved.Chunker, strings.Join(Names(), ", "))
+	}
+
+	chunks := info.Chunk(in, int(resolved.MaxTokens), int(resolved.OverlapTokens))
+	for i, chunk := range chunks {
+		chunk.EntryName = entryName
+		chunk.Ordinal = int32(i)
+		chunk.TokenCount = int32(CountTokens(chunk.Text))
+		chunk.Digest = fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(chunk.Text)))
+		chunk.Simhash = diversify.SimHash(chunk.Text)
+	}
+
+	return chunks, nil
+}
+
+// CountTokens returns the approximate number of tokens in text.
+func CountTokens(text string) int {
+	return len(tokenRegex.FindAllStringIndex(text, -1))
+}
+
+// span is a byte range of a text, end exclusive.
+type span struct {
+	start, end int
+}
+
+// tokenWindows splits text into windows of at most maxTokens tokens, each starting overlapTokens tokens
+// before the end of the previous one. Windows start and end on token boundaries.
+func tokenWindows(text string, maxTokens int, overlapTokens int) []span {
+	tokens := tokenRegex.FindAllStringIndex(text, -1)
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	step := maxTokens - overlapTokens
+	if step < 1 {
+		step = 1
+	}
+
+	var windows []span
+	for i := 0; i < len(tokens); i += step {
+		j := min(i+maxTokens, len(tokens))
+		windows = append(windows, span{tokens[i][0], tokens[j-1][1]})
+		if j == len(tokens) {
+			break
+		}
+	}
+	return windows
+}
+
+// text returns the content as text, or "" if it is not valid UTF-8 and so cannot be chunked directly.
+func (in *Input) text() string {
+	if !utf8.Valid(in.Content) {
+		return ""
+	}
+	return string(in.Content)
+}
+
+// contentChunk creates a chunk for a byte range of the content, with its line range.
+func contentChunk(content string, s span, chunkPath string) *pb.Chunk {
+	return &pb.Chunk{
+		StartByte: int64(s.start),
+		EndByte:   int64(s.end),
+		StartLine: int32(strings.Count(content[:s.start], "\n") + 1),
+		EndLine:   int32(strings.Count(content[:s.end], "\n") + 1),
+		Text:      content[s.start:s.end],
+		Path:      chunkPath,
+	}
+}
+
+// splitSpan splits a byte range of the content into chunks of at most maxTokens tokens, as a single chunk if it fits.
+func splitSpan(content string, s span, maxTokens int, overlapTokens int, chunkPath string) []*pb.Chunk {
+	var chunks []*pb.Chunk
+	for _, w := range tokenWindows(content[s.start:s.end], maxTokens, overlapTokens) {
+		chunks = append(chunks, contentChunk(content, span{s.start + w.start, s.start + w.end}, chunkPath))
+	}
+	return chunks
+}
+
+func chunkTokens(in *Input, maxTokens int, overlapTokens int) []*pb.Chunk {
+	content := in.text()
+	if content == "" {
+		return chunkSections(in, maxTokens, overlapTokens)
+	}
+	return splitSpan(content, span{0, len(content)}, maxTokens, overlapTokens, "")
+}
+
+func chunkParagraphs(in *Input, maxTokens int, overlapTokens int) []*pb.Chunk {
+	content := in.text()
+	if content == "" {
+		return chunkSections(in, maxTokens, overlapTokens)
+	}
+
+	var paragraphs []span
+	start := 0
+	for _, brk := range paragraphBreakRegex.FindAllStringIndex(content, -1) {
+		paragraphs = append(paragraphs, span{start, brk[0]})
+		start = brk[1]
+	}
+	paragraphs = append(paragraphs, span{start, len(content)})
+
+	return mergeSpans(content, paragraphs, maxTokens, overlapTokens, nil)
+}
+
+// mergeSpans joins consecutive spans of the content into chunks of up to maxTokens tokens. Spans that are
+// too long on their own are split into token windows. paths optionally names the chunk of each span,
+// and spans with different paths are never merged.
+func mergeSpans(content string, spans []span, maxTokens int, overlapTokens int, paths []string) []*pb.Chunk {
+	var chunks []*pb.Chunk
+	current := span{-1, -1}
+	currentTokens := 0
+	currentPath := ""
+
+	flush := func() {
+		if current.start >= 0 {
+			chunks = append(chunks, contentChunk(content, current, currentPath))
+		}
+		current = span{-1, -1}
+		currentTokens = 0
+	}
+
+	for i, s := range spans {
+		s = trimSpan(content, s)
+		tokens := CountTokens(content[s.start:s.end])
+		if tokens == 0 {
+			continue
+		}
+
+		spanPath := ""
+		if paths != nil {
+			spanPath = paths[i]
+		}
+
+		if tokens > maxTokens {
+			flush()
+			chunks = append(chunks, splitSpan(content, s, maxTokens, overlapTokens, spanPath)...)
+			continue
+		}
+
+		if current.start >= 0 && (currentTokens+tokens > maxTokens || spanPath != currentPath) {
+			flush()
+		}
+		if current.start < 0 {
+			current = s
+			currentPath = spanPath
+		} else {
+			current.end = s.end
+		}
+		currentTokens += tokens
+	}
+	flush()
+
+	return chunks
+}
+
+// trimSpan shrinks a span to exclude leading and trailing whitespace.
+func trimSpan(content string, s span) span {
+	for s.start < s.end && isSpace(content[s.start]) {
+		s.start++
+	}
+	for s.end > s.start && isSpace(content[s.end-1]) {
+		s.end--
+	}
+	return s
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func chunkSections(in *Input, maxTokens int, overlapTokens int) []*pb.Chunk {
+	if len(in.Sections) == 0 {
+		if in.text() == "" {
+			return nil
+		}
+		return chunkParagraphs(in, maxTokens, overlapTokens)
+	}
+
+	lineStarts := lineOffsets(in.Content)
+
+	var chunks []*pb.Chunk
+	for _, section := range in.Sections {
+		windows := tokenWindows(section.Text, maxTokens, overlapTokens)
+		for _, w := range windows {
+			chunk := &pb.Chunk{
+				StartLine: section.StartLine,
+				EndLine:   section.EndLine,
+				Text:      section.Text,
+				Path:      section.Path,
+			}
+			if len(windows) > 1 {
+				chunk.Text = section.Text[w.start:w.end]
+			}
+
+			// the text of a section is not necessarily verbatim, but its lines are
+			if section.StartLine > 0 && int(section.EndLine) <= len(lineStarts) && utf8.Valid(in.Content) {
+				chunk.StartByte = int64(lineStarts[section.StartLine-1])
+				chunk.EndByte = int64(len(in.Content))
+				if int(section.EndLine) < len(lineStarts) {
+					chunk.EndByte = int64(lineStarts[section.EndLine])
+				}
+			}
+
+			chunks = append(chunks, chunk)
+		}
+	}
+
+	return chunks
+}
+
+// lineOffsets returns the byte offset at which each line of content starts.
+func lineOffsets(content []byte) []int {
+	offsets := []int{0}
+	for i := 0; i < len(content); {
+		j := bytes.IndexByte(content[i:], '\n')
+		if j < 0 {
+			break
+		}
+		i += j + 1
+		offsets = append(offsets, i)
+	}
+	return offsets
+}
+
+var codeExtensions = map[string]bool{
+	".go": true, ".py": true, ".js": true, ".jsx": true, ".ts": true, ".tsx": true, ".java": true,
+	".kt": true, ".rs": true, ".rb": true, ".c": true, ".h": true, ".cc": true, ".cpp": true,
+	".hpp": true, ".cs": true, ".swift": true, ".php": true, ".scala": true,
+}
+
+// IsCode reports whether uri names a source code file, based on its extension.
+func IsCode(uri string) bool {
+	return codeExtensions[strings.ToLower(path.Ext(uri))]
+}
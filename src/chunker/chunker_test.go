@@ -0,0 +1,212 @@
+package chunker
+
+import (
+	"strings"
+	"testing"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *pb.ChunkingConfig
+		uri         string
+		hasSections bool
+		expected    *pb.ChunkingConfig
+	}{
+		{
+			name:     "Defaults for text",
+			uri:      "notes.txt",
+			expected: &pb.ChunkingConfig{Chunker: "paragraph", MaxTokens: DefaultMaxTokens, OverlapTokens: DefaultOverlapTokens},
+		},
+		{
+			name:        "Defaults with sections",
+			uri:         "README.md",
+			hasSections: true,
+			expected:    &pb.ChunkingConfig{Chunker: "section", MaxTokens: DefaultMaxTokens, OverlapTokens: DefaultOverlapTokens},
+		},
+		{
+			name:     "Defaults for code",
+			uri:      "main.go",
+			expected: &pb.ChunkingConfig{Chunker: "symbol", MaxTokens: DefaultMaxTokens, OverlapTokens: DefaultOverlapTokens},
+		},
+		{
+			name:     "Negative overlap disables it",
+			config:   &pb.ChunkingConfig{Chunker: "tokens", MaxTokens: 100, OverlapTokens: -1},
+			expected: &pb.ChunkingConfig{Chunker: "tokens", MaxTokens: 100, OverlapTokens: 0},
+		},
+		{
+			name:     "Overlap capped to half",
+			config:   &pb.ChunkingConfig{MaxTokens: 10, OverlapTokens: 20},
+			uri:      "notes.txt",
+			expected: &pb.ChunkingConfig{Chunker: "paragraph", MaxTokens: 10, OverlapTokens: 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Resolve(tt.config, tt.uri, tt.hasSections)
+			if got.Chunker != tt.expected.Chunker || got.MaxTokens != tt.expected.MaxTokens || got.OverlapTokens != tt.expected.OverlapTokens {
+				t.Errorf("Resolve() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestChunk_Tokens(t *testing.T) {
+	content := "one two three four five six seven eight nine ten"
+	chunks, err := Chunk("numbers", &Input{URI: "numbers.txt", Content: []byte(content)},
+		&pb.ChunkingConfig{Chunker: "tokens", MaxTokens: 4, OverlapTokens: 1})
+	if err != nil {
+		t.Fatalf("Chunk() returned unexpected error: %v", err)
+	}
+
+	expected := []string{"one two three four", "four five six seven", "seven eight nine ten"}
+	if len(chunks) != len(expected) {
+		t.Fatalf("Expected %d chunks, got %d", len(expected), len(chunks))
+	}
+
+	for i, chunk := range chunks {
+		if chunk.Text != expected[i] {
+			t.Errorf("Chunk %d: expected %q, got %q", i, expected[i], chunk.Text)
+		}
+		if chunk.Ordinal != int32(i) || chunk.EntryName != "numbers" || chunk.TokenCount != 4 {
+			t.Errorf("Chunk %d: unexpected ordinal %d, entry %s or token count %d", i, chunk.Ordinal, chunk.EntryName, chunk.TokenCount)
+		}
+		if content[chunk.StartByte:chunk.EndByte] != chunk.Text {
+			t.Errorf("Chunk %d: byte range %d-%d does not match its text", i, chunk.StartByte, chunk.EndByte)
+		}
+		if !strings.HasPrefix(chunk.Digest, "sha256:") {
+			t.Errorf("Chunk %d: unexpected digest %s", i, chunk.Digest)
+		}
+	}
+}
+
+func TestChunk_Paragraphs(t *testing.T) {
+	content := "First paragraph.\n\nSecond paragraph.\n\n\nA third paragraph that is longer than the others."
+	chunks, err := Chunk("doc", &Input{URI: "doc.txt", Content: []byte(content)}, &pb.ChunkingConfig{MaxTokens: 8})
+	if err != nil {
+		t.Fatalf("Chunk() returned unexpected error: %v", err)
+	}
+
+	if len(chunks) != 3 {
+		t.Fatalf("Expected 3 chunks, got %d: %v", len(chunks), chunks)
+	}
+
+	if chunks[0].Text != "First paragraph.\n\nSecond paragraph." {
+		t.Errorf("Expected the first two paragraphs to be merged, got %q", chunks[0].Text)
+	}
+	if chunks[0].StartLine != 1 || chunks[0].EndLine != 3 {
+		t.Errorf("Expected lines 1-3, got %d-%d", chunks[0].StartLine, chunks[0].EndLine)
+	}
+
+	// the third paragraph is too long for one chunk and is split into overlapping windows
+	if !strings.HasPrefix(chunks[1].Text, "A third") || chunks[1].StartLine != 6 {
+		t.Errorf("Unexpected second chunk %q at line %d", chunks[1].Text, chunks[1].StartLine)
+	}
+	if !strings.HasSuffix(chunks[2].Text, "others.") {
+		t.Errorf("Unexpected last chunk %q", chunks[2].Text)
+	}
+}
+
+func TestChunk_Sections(t *testing.T) {
+	content := "# Title\nIntro\n## Usage\nRun it.\n"
+	sections := []*pb.Section{
+		{Path: "Title", Text: "Title\nIntro", StartLine: 1, EndLine: 2},
+		{Path: "Title > Usage", Text: "Usage\nRun it.", StartLine: 3, EndLine: 4},
+	}
+
+	chunks, err := Chunk("readme", &Input{URI: "README.md", Content: []byte(content), Sections: sections}, nil)
+	if err != nil {
+		t.Fatalf("Chunk() returned unexpected error: %v", err)
+	}
+
+	if len(chunks) != 2 {
+		t.Fatalf("Expected 2 chunks, got %d", len(chunks))
+	}
+
+	usage := chunks[1]
+	if usage.Path != "Title > Usage" || usage.Text != "Usage\nRun it." || usage.StartLine != 3 || usage.EndLine != 4 {
+		t.Errorf("Unexpected chunk: %v", usage)
+	}
+	if content[usage.StartByte:usage.EndByte] != "## Usage\nRun it.\n" {
+		t.Errorf("Unexpected byte range %d-%d", usage.StartByte, usage.EndByte)
+	}
+}
+
+func TestChunk_Symbols(t *testing.T) {
+	tests := []struct {
+		name          string
+		uri           string
+		content       string
+		expectedPaths []string
+		expectedFirst []string
+	}{
+		{
+			name: "Go",
+			uri:  "server.go",
+			content: `package main
+
+import "fmt"
+
+// Server serves requests.
+type Server struct {
+	name string
+}
+
+// Start starts the server.
+func (s *Server) Start() {
+	fmt.Println(s.name)
+}
+`,
+			expectedPaths: []string{"", "Server", "Start"},
+			expectedFirst: []string{"package main", "// Server serves requests.", "// Start starts the server."},
+		},
+		{
+			name: "Python",
+			uri:  "app.py",
+			content: `import os
+
+@dataclass
+class Config:
+    path: str
+
+# Loads the config.
+def load():
+    return Config(os.getcwd())
+`,
+			expectedPaths: []string{"", "Config", "load"},
+			expectedFirst: []string{"import os", "@dataclass", "# Loads the config."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks, err := Chunk("code", &Input{URI: tt.uri, Content: []byte(tt.content)}, nil)
+			if err != nil {
+				t.Fatalf("Chunk() returned unexpected error: %v", err)
+			}
+
+			if len(chunks) != len(tt.expectedPaths) {
+				t.Fatalf("Expected %d chunks, got %d: %v", len(tt.expectedPaths), len(chunks), chunks)
+			}
+
+			for i, chunk := range chunks {
+				if chunk.Path != tt.expectedPaths[i] {
+					t.Errorf("Chunk %d: expected path %q, got %q", i, tt.expectedPaths[i], chunk.Path)
+				}
+				if firstLine, _, _ := strings.Cut(chunk.Text, "\n"); firstLine != tt.expectedFirst[i] {
+					t.Errorf("Chunk %d: expected first line %q, got %q", i, tt.expectedFirst[i], firstLine)
+				}
+			}
+		})
+	}
+}
+
+func TestChunk_UnknownChunker(t *testing.T) {
+	_, err := Chunk("doc", &Input{Content: []byte("text")}, &pb.ChunkingConfig{Chunker: "sentences"})
+	if err == nil {
+		t.Error("Expected an error for an unknown chunker")
+	}
+}
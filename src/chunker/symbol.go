@@ -0,0 +1,57 @@
+package chunker
+
+import (
+	"regexp"
+	"strings"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+// symbolRegex matches unindented declarations of functions, methods, classes and types in common languages,
+// eg. "func (s *Server) Add(", "def main(", "export class Foo", "pub fn bar", "public static void main(".
+var symbolRegex = regexp.MustCompile(`^(?:(?:export|default|public|private|protected|internal|static|final|abstract|async|pub(?:\([a-z]+\))?|unsafe|extern|inline|virtual)\s+)*` +
+	`(?:func|def|class|type|interface|struct|enum|trait|impl|fn|function|module|object|record)\s+(?:\([^)]*\)\s*)?([A-Za-z_$][\w$]*)`)
+
+// Lines that belong to the declaration that follows them, such as doc comments, decorators and annotations.
+var leadingLineRegex = regexp.MustCompile(`^\s*(?://|#|/\*|\*|@|\[)`)
+
+// chunkSymbols splits source code at its top-level declarations. Each chunk holds one declaration with the
+// comments and decorators above it, and is named after the declared symbol; code before the first
+// declaration, such as imports, forms its own chunk. Declarations longer than maxTokens are split into windows.
+func chunkSymbols(in *Input, maxTokens int, overlapTokens int) []*pb.Chunk {
+	content := in.text()
+	if content == "" {
+		return chunkSections(in, maxTokens, overlapTokens)
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+	offsets := make([]int, len(lines)+1)
+	for i, line := range lines {
+		offsets[i+1] = offsets[i] + len(line)
+	}
+
+	var spans []span
+	var paths []string
+	start, startLine, name := 0, 0, ""
+	for i, line := range lines {
+		m := symbolRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		// attach the comments and decorators directly above the declaration
+		first := i
+		for first > startLine && leadingLineRegex.MatchString(lines[first-1]) && strings.TrimSpace(lines[first-1]) != "" {
+			first--
+		}
+		if offsets[first] > start {
+			spans = append(spans, span{start, offsets[first]})
+			paths = append(paths, name)
+		}
+		start, startLine, name = offsets[first], i+1, m[1]
+	}
+	spans = append(spans, span{start, len(content)})
+	paths = append(paths, name)
+
+	return mergeSpans(content, spans, maxTokens, overlapTokens, paths)
+}
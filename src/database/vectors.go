@@ -0,0 +1,279 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Distance metrics of vector searches and indexes. Scores are the cosine similarity or the dot product.
+const (
+	MetricCosine = "cosine"
+	MetricDot    = "dot"
+)
+
+var indexNameRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ScoredChunk is a chunk found by a vector search, with its score against the query, higher meaning closer.
+type ScoredChunk struct {
+	Chunk *pb.Chunk
+	Score float64
+}
+
+// InitializeVectorSchema enables the pgvector extension and creates the chunk_embeddings table, holding the
+// chunks of the entries of index_list along with their vectors. Vectors of any dimension and model share the
+// table; searches and indexes are restricted to one model, and cast vectors to its dimension.
+func InitializeVectorSchema(ctx context.Context, conn *PgxIface) error {
+	if ctx == nil {
+		return errors.New("context is nil")
+	}
+
+	if conn == nil {
+		return errors.New("connection interface is nil")
+	}
+
+	if _, err := (*conn).Exec(ctx, `CREATE EXTENSION IF NOT EXISTS vector`); err != nil {
+		return fmt.Errorf("failed to create the pgvector extension: %w", err)
+	}
+
+	_, err := (*conn).Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS chunk_embeddings (
+			entry_name TEXT NOT NULL REFERENCES index_list(name) ON DELETE CASCADE,
+			ordinal INTEGER NOT NULL,
+			chunk JSONB,
+			model TEXT NOT NULL,
+			digest TEXT NOT NULL,
+			embedding vector NOT NULL,
+			PRIMARY KEY (entry_name, ordinal)
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create the table: %w", err)
+	}
+
+	return nil
+}
+
+// CreateHNSWIndex creates an HNSW index of the vectors of a model, so that searches with the metric are
+// approximate but fast. m and efConstruction tune the graph; pgvector's defaults are used when they are 0.
+func CreateHNSWIndex(ctx context.Context, conn *PgxIface, model string, dimension int, metric string, m int, efConstruction int) error {
+	var options []string
+	if m > 0 {
+		options = append(options, "m = "+strconv.Itoa(m))
+	}
+	if efConstruction > 0 {
+		options = append(options, "ef_construction = "+strconv.Itoa(efConstruction))
+	}
+
+	return createVectorIndex(ctx, conn, "hnsw", model, dimension, metric, options)
+}
+
+// CreateIVFFlatIndex creates an IVFFlat index of the vectors of a model, partitioning them into lists.
+// It should be created once the table holds most of the vectors, since the lists are chosen from them.
+func CreateIVFFlatIndex(ctx context.Context, conn *PgxIface, model string, dimension int, metric string, lists int) error {
+	var options []string
+	if lists > 0 {
+		options = append(options, "lists = "+strconv.Itoa(lists))
+	}
+
+	return createVectorIndex(ctx, conn, "ivfflat", model, dimension, metric, options)
+}
+
+func createVectorIndex(ctx context.Context, conn *PgxIface, method string, model string, dimension int, metric string, options []string) error {
+	if ctx == nil {
+		return errors.New("context is nil")
+	}
+
+	if conn == nil {
+		return errors.New("connection interface is nil")
+	}
+
+	if dimension <= 0 {
+		return fmt.Errorf("invalid dimension %d", dimension)
+	}
+
+	operator, err := operatorClass(metric)
+	if err != nil {
+		return err
+	}
+
+	indexName := "idx_chunk_embeddings_" + method + "_" + strings.Trim(indexNameRegex.ReplaceAllString(strings.ToLower(model), "_"), "_") +
+		"_" + strconv.Itoa(dimension) + "_" + metricName(metric)
+	query := `CREATE INDEX IF NOT EXISTS ` + pgx.Identifier{indexName}.Sanitize() + ` ON chunk_embeddings USING ` + method +
+		` ((embedding::vector(` + strconv.Itoa(dimension) + `)) ` + operator + `)`
+	if len(options) > 0 {
+		query += ` WITH (` + strings.Join(options, ", ") + `)`
+	}
+	query += ` WHERE model = ` + quoteLiteral(model)
+
+	if _, err := (*conn).Exec(ctx, query); err != nil {
+		return fmt.Errorf("failed to create vector index: %w", err)
+	}
+
+	return nil
+}
+
+// UpsertChunkEmbeddings replaces the stored chunks of the given entries with those that have an embedding.
+func UpsertChunkEmbeddings(ctx context.Context, conn *PgxIface, entries []*pb.IndexListEntry) error {
+	if ctx == nil {
+		return errors.New("context is nil")
+	}
+
+	if conn == nil {
+		return errors.New("connection interface is nil")
+	}
+
+	tx, err := (*conn).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to connect to database: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	batch := &pgx.Batch{}
+	for _, ile := range entries {
+		batch.Queue(`DELETE FROM chunk_embeddings WHERE entry_name = $1`, ile.Name)
+
+		for _, chunk := range ile.Chunks {
+			embedding := chunk.GetEmbedding()
+			if len(embedding.GetVector()) == 0 {
+				continue
+			}
+
+			// the vector is stored in its own column rather than twice
+			stored := proto.Clone(chunk).(*pb.Chunk)
+			stored.Embedding = nil
+			chunkJson, err := protojson.Marshal(stored)
+			if err != nil {
+				return fmt.Errorf("failed to marshal protobuf to JSON: %w", err)
+			}
+
+			batch.Queue(`
+				INSERT INTO chunk_embeddings(entry_name, ordinal, chunk, model, digest, embedding)
+				VALUES($1, $2, $3, $4, $5, $6::vector)
+			`, ile.Name, chunk.Ordinal, chunkJson, embedding.Model, embedding.Digest, vectorLiteral(embedding.Vector))
+		}
+	}
+
+	br := tx.SendBatch(ctx, batch)
+	for i := 0; i < batch.Len(); i++ {
+		if _, err := br.Exec(); err != nil {
+			br.Close()
+			return fmt.Errorf("failed to upsert chunk embeddings: %w", err)
+		}
+	}
+	br.Close()
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// SearchChunkEmbeddings returns the k chunks whose vectors of the model are closest to the query, closest first.
+func SearchChunkEmbeddings(ctx context.Context, conn *PgxIface, model string, query []float32, metric string, k int) ([]*ScoredChunk, error) {
+	if ctx == nil {
+		return nil, errors.New("context is nil")
+	}
+
+	if conn == nil {
+		return nil, errors.New("connection interface is nil")
+	}
+
+	if len(query) == 0 {
+		return nil, errors.New("query vector is empty")
+	}
+
+	// the operators are distances: 1 - cosine similarity, and the negated dot product
+	var distance, score string
+	vectorType := `vector(` + strconv.Itoa(len(query)) + `)`
+	switch metricName(metric) {
+	case MetricCosine:
+		distance = `embedding::` + vectorType + ` <=> $1::` + vectorType
+		score = `1 - (` + distance + `)`
+	case MetricDot:
+		distance = `embedding::` + vectorType + ` <#> $1::` + vectorType
+		score = `-(` + distance + `)`
+	default:
+		return nil, fmt.Errorf("unknown metric %s, expected %s or %s", metric, MetricCosine, MetricDot)
+	}
+
+	rows, err := (*conn).Query(ctx, `
+		SELECT chunk, `+score+`
+		FROM chunk_embeddings
+		WHERE model = $2
+		ORDER BY `+distance+`
+		LIMIT $3
+	`, vectorLiteral(query), model, k)
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*ScoredChunk
+	for rows.Next() {
+		var chunkJson []byte
+		var s float64
+		if err := rows.Scan(&chunkJson, &s); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		chunk := &pb.Chunk{}
+		if err := protojson.Unmarshal(chunkJson, chunk); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal chunk JSON to protobuf: %w", err)
+		}
+		results = append(results, &ScoredChunk{Chunk: chunk, Score: s})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return results, nil
+}
+
+func metricName(metric string) string {
+	if metric == "" {
+		return MetricCosine
+	}
+	return metric
+}
+
+func operatorClass(metric string) (string, error) {
+	switch metricName(metric) {
+	case MetricCosine:
+		return "vector_cosine_ops", nil
+	case MetricDot:
+		return "vector_ip_ops", nil
+	default:
+		return "", fmt.Errorf("unknown metric %s, expected %s or %s", metric, MetricCosine, MetricDot)
+	}
+}
+
+// vectorLiteral formats a vector as pgvector's text representation, eg. "[1,0.5,-2]".
+func vectorLiteral(vector []float32) string {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for i, v := range vector {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(strconv.FormatFloat(float64(v), 'g', -1, 32))
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// quoteLiteral quotes a string as an SQL literal, for statements that cannot take parameters such as the
+// predicate of a partial index.
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestChunkEmbeddings(t *testing.T) {
+	// Test connection
+	ctx := context.Background()
+	conn, err := pgx.Connect(ctx, os.Getenv("DATABASE_URL"))
+	if err != nil {
+		t.Fatalf("Failed to establish connection to the database: %v", err)
+	}
+	defer conn.Close(ctx)
+
+	var dbConn PgxIface = conn
+
+	if err := InitializeDatabaseSchema(ctx, &dbConn); err != nil {
+		t.Fatalf("Failed to initialise the database schema: %v", err)
+	}
+	if err := InitializeVectorSchema(ctx, &dbConn); err != nil {
+		t.Fatalf("Failed to initialise the vector schema: %v", err)
+	}
+
+	embedded := func(ordinal int32, text string, vector ...float32) *pb.Chunk {
+		return &pb.Chunk{
+			EntryName: "Vector Entry",
+			Ordinal:   ordinal,
+			Text:      text,
+			Digest:    "sha256:" + text,
+			Embedding: &pb.Embedding{Model: "test-model", Digest: "sha256:" + text, Vector: vector},
+		}
+	}
+	entry := &pb.IndexListEntry{
+		Name:            "Vector Entry",
+		ContentMetadata: &pb.ContentMetadata{URI: "/docs/vectors.md", DataType: pb.DataType_MARKDOWN},
+		Chunks: []*pb.Chunk{
+			embedded(0, "east", 1, 0, 0),
+			embedded(1, "north", 0, 1, 0),
+			embedded(2, "up", 0, 0, 1),
+			{EntryName: "Vector Entry", Ordinal: 3, Text: "not embedded"},
+		},
+	}
+
+	if err := InsertRows(ctx, &dbConn, &pb.Index{Entries: []*pb.IndexListEntry{entry}}); err != nil {
+		t.Fatalf("Failed to insert rows: %v", err)
+	}
+	if err := UpsertChunkEmbeddings(ctx, &dbConn, []*pb.IndexListEntry{entry}); err != nil {
+		t.Fatalf("Failed to upsert chunk embeddings: %v", err)
+	}
+
+	if err := CreateHNSWIndex(ctx, &dbConn, "test-model", 3, MetricCosine, 8, 32); err != nil {
+		t.Fatalf("Failed to create an HNSW index: %v", err)
+	}
+	if err := CreateIVFFlatIndex(ctx, &dbConn, "test-model", 3, MetricDot, 1); err != nil {
+		t.Fatalf("Failed to create an IVFFlat index: %v", err)
+	}
+
+	results, err := SearchChunkEmbeddings(ctx, &dbConn, "test-model", []float32{0.1, 1, 0}, MetricCosine, 2)
+	if err != nil {
+		t.Fatalf("Failed to search chunk embeddings: %v", err)
+	}
+	if len(results) != 2 || results[0].Chunk.Text != "north" || results[0].Chunk.Embedding != nil || results[0].Score <= results[1].Score {
+		t.Errorf("Expected the north chunk first, got %v", results)
+	}
+
+	results, err = SearchChunkEmbeddings(ctx, &dbConn, "other-model", []float32{0.1, 1, 0}, MetricCosine, 2)
+	if err != nil || len(results) != 0 {
+		t.Errorf("Expected no vectors of another model, got %v (%v)", results, err)
+	}
+
+	// Deleting the entry deletes its chunks
+	if err := DeleteRows(ctx, &dbConn, []string{"Vector Entry"}); err != nil {
+		t.Fatalf("Failed to delete rows: %v", err)
+	}
+	results, err = SearchChunkEmbeddings(ctx, &dbConn, "test-model", []float32{0.1, 1, 0}, MetricCosine, 2)
+	if err != nil || len(results) != 0 {
+		t.Errorf("Expected the chunks of a deleted entry to be deleted, got %v (%v)", results, err)
+	}
+}
@@ -0,0 +1,53 @@
+package diversify
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSimHash(t *testing.T) {
+	original := `func (s *Server) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %v", s.addr, err)
+	}
+	s.listener = listener
+	go s.serve(ctx)
+	return nil
+}`
+	vendored := original + "\n// Copied from upstream"
+	unrelated := "Apples and bananas are both fruit, but only one of them grows in bunches on a tree."
+
+	if SimHash(original) != SimHash(original) {
+		t.Error("Expected SimHash to be deterministic")
+	}
+	if d := Distance(SimHash(original), SimHash(vendored)); d > DuplicateDistance {
+		t.Errorf("Expected near duplicates to be within %d bits, got %d", DuplicateDistance, d)
+	}
+	if d := Distance(SimHash(original), SimHash(unrelated)); d <= DuplicateDistance {
+		t.Errorf("Expected unrelated texts to differ by more than %d bits, got %d", DuplicateDistance, d)
+	}
+	if SimHash("  ") != 0 {
+		t.Error("Expected a fingerprint of 0 for a text without words")
+	}
+}
+
+func TestGroup(t *testing.T) {
+	fingerprints := []uint64{0, 0xFFFF, 0b0111_1111, 0xFF00, 0xFFFE}
+	if groups := Group(fingerprints); !reflect.DeepEqual(groups, []int{0, 1, 0, 3, 1}) {
+		t.Errorf("Unexpected groups: %v", groups)
+	}
+}
+
+func TestMMR(t *testing.T) {
+	relevance := []float64{0.9, 0.85, 0.5}
+	vectors := [][]float32{{1, 0}, {1, 0.01}, {0, 1}}
+
+	// pure relevance keeps the order, while diversity moves the near copy of the first result last
+	if order := MMR(relevance, vectors, 1); !reflect.DeepEqual(order, []int{0, 1, 2}) {
+		t.Errorf("Unexpected order with lambda 1: %v", order)
+	}
+	if order := MMR(relevance, vectors, DefaultLambda); !reflect.DeepEqual(order, []int{0, 2, 1}) {
+		t.Errorf("Unexpected order with lambda %g: %v", DefaultLambda, order)
+	}
+}
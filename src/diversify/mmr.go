@@ -0,0 +1,55 @@
+package diversify
+
+import (
+	"math"
+
+	"accretional.com/semantifly/embedder"
+)
+
+const DefaultLambda = 0.5
+
+// MMR orders results by maximal marginal relevance: each pick is the result maximizing
+// lambda * relevance - (1 - lambda) * its highest cosine similarity to the results already picked.
+// Relevance scores are scaled to [0, 1] so that they are comparable to similarities. vectors holds the vector of
+// each result, and the indexes of the results are returned in the order they are picked.
+func MMR(relevance []float64, vectors [][]float32, lambda float64) []int {
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, r := range relevance {
+		low, high = min(low, r), max(high, r)
+	}
+	normalized := make([]float64, len(relevance))
+	for i, r := range relevance {
+		normalized[i] = 1
+		if high > low {
+			normalized[i] = (r - low) / (high - low)
+		}
+	}
+
+	// highest similarity of each remaining result to those picked
+	similarity := make([]float64, len(relevance))
+	picked := make([]bool, len(relevance))
+	order := make([]int, 0, len(relevance))
+
+	for len(order) < len(relevance) {
+		best, bestScore := -1, math.Inf(-1)
+		for i := range relevance {
+			if picked[i] {
+				continue
+			}
+			score := lambda*normalized[i] - (1-lambda)*similarity[i]
+			if score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+
+		picked[best] = true
+		order = append(order, best)
+		for i := range relevance {
+			if !picked[i] {
+				similarity[i] = max(similarity[i], float64(embedder.Cosine(vectors[i], vectors[best])))
+			}
+		}
+	}
+
+	return order
+}
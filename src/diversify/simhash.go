@@ -0,0 +1,76 @@
+package diversify
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"regexp"
+	"strings"
+)
+
+// DuplicateDistance is the largest number of differing bits between the fingerprints of near duplicates. It is
+// looser than the usual 3 bits for web pages since chunks are short, so that a few edited words move more bits.
+const DuplicateDistance = 7
+
+const shingleSize = 2
+
+var wordRegex = regexp.MustCompile(`[\p{L}\p{N}_]+`)
+
+// SimHash returns a 64 bit fingerprint of text such that texts sharing most of their words in the same order
+// get fingerprints differing by few bits. Each pair of adjacent words is hashed, and every bit of the fingerprint is
+// set if it is set in most of the hashes. Case and punctuation are ignored. Empty texts have a fingerprint of 0.
+func SimHash(text string) uint64 {
+	words := wordRegex.FindAllString(strings.ToLower(text), -1)
+	if len(words) == 0 {
+		return 0
+	}
+
+	var counts [64]int
+	size := min(shingleSize, len(words))
+	for i := 0; i+size <= len(words); i++ {
+		h := fnv.New64a()
+		h.Write([]byte(strings.Join(words[i:i+size], " ")))
+		sum := h.Sum64()
+
+		for bit := range counts {
+			if sum&(1<<bit) != 0 {
+				counts[bit]++
+			} else {
+				counts[bit]--
+			}
+		}
+	}
+
+	var fingerprint uint64
+	for bit, count := range counts {
+		if count > 0 {
+			fingerprint |= 1 << bit
+		}
+	}
+	return fingerprint
+}
+
+// Distance returns the number of bits by which two fingerprints differ.
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// Group assigns each fingerprint to a group of near duplicates, returning the index of the first fingerprint of
+// its group. A fingerprint joins the group of the first earlier fingerprint within DuplicateDistance of it, so
+// the first of each group can stand for the others when fingerprints are sorted by relevance.
+func Group(fingerprints []uint64) []int {
+	groups := make([]int, len(fingerprints))
+	var representatives []int
+	for i, fingerprint := range fingerprints {
+		groups[i] = i
+		for _, r := range representatives {
+			if Distance(fingerprint, fingerprints[r]) <= DuplicateDistance {
+				groups[i] = r
+				break
+			}
+		}
+		if groups[i] == i {
+			representatives = append(representatives, i)
+		}
+	}
+	return groups
+}
@@ -0,0 +1,92 @@
+package embedder
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+// Embedder turns texts into vectors whose cosine similarity reflects how related the texts are.
+type Embedder interface {
+	// Embed returns one vector per text, in order.
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+	// Dimension is the length of the vectors, or 0 if it is not known before the first call to Embed.
+	Dimension() int
+	// Model identifies the model, so that vectors of different models are never compared.
+	Model() string
+}
+
+type ProviderInfo struct {
+	Description string
+	New         func(config *pb.EmbeddingConfig) (Embedder, error)
+}
+
+var providerDict = map[string]ProviderInfo{
+	"hash": {
+		Description: "Offline and deterministic: hashed, stemmed terms projected onto a fixed number of dimensions",
+		New:         newHashEmbedder,
+	},
+	"openai": {
+		Description: "Any server implementing the OpenAI /v1/embeddings API, eg. OpenAI, Ollama or llama.cpp",
+		New:         newOpenAIEmbedder,
+	},
+}
+
+// Providers returns the names of the available embedding providers, sorted.
+func Providers() []string {
+	names := make([]string, 0, len(providerDict))
+	for name := range providerDict {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New creates the Embedder described by config. A nil config or an empty provider results in the hash embedder.
+func New(config *pb.EmbeddingConfig) (Embedder, error) {
+	provider := config.GetProvider()
+	if provider == "" {
+		provider = "hash"
+	}
+
+	info, ok := providerDict[provider]
+	if !ok {
+		return nil, fmt.Errorf("unknown embedding provider %s, expected one of %s", provider, strings.Join(Providers(), ", "))
+	}
+
+	return info.New(config)
+}
+
+// Normalize scales a vector to unit length in place, leaving zero vectors as they are.
+func Normalize(vector []float32) {
+	var sum float64
+	for _, v := range vector {
+		sum += float64(v) * float64(v)
+	}
+	if sum == 0 {
+		return
+	}
+
+	norm := float32(math.Sqrt(sum))
+	for i := range vector {
+		vector[i] /= norm
+	}
+}
+
+// Cosine returns the cosine similarity of two vectors of the same length.
+func Cosine(a, b []float32) float32 {
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return float32(dot / math.Sqrt(normA*normB))
+}
@@ -0,0 +1,118 @@
+package embedder
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+func TestNew(t *testing.T) {
+	e, err := New(nil)
+	if err != nil {
+		t.Fatalf("New() returned unexpected error: %v", err)
+	}
+	if e.Model() != "hash-256" || e.Dimension() != DefaultHashDimensions {
+		t.Errorf("Expected the default hash embedder, got %s with %d dimensions", e.Model(), e.Dimension())
+	}
+
+	if _, err := New(&pb.EmbeddingConfig{Provider: "unknown"}); err == nil {
+		t.Error("Expected an error for an unknown provider")
+	}
+	if _, err := New(&pb.EmbeddingConfig{Provider: "openai"}); err == nil {
+		t.Error("Expected an error for an openai provider without a model")
+	}
+}
+
+func TestHashEmbedder(t *testing.T) {
+	e := NewHashEmbedder(128)
+
+	texts := []string{
+		"The users table stores user accounts and their emails",
+		"Each user account has an email address in the users table",
+		"Kubernetes deployments roll out new container images",
+	}
+
+	vectors, err := e.Embed(context.Background(), texts)
+	if err != nil {
+		t.Fatalf("Embed() returned unexpected error: %v", err)
+	}
+
+	if len(vectors) != 3 || len(vectors[0]) != 128 {
+		t.Fatalf("Expected 3 vectors of 128 dimensions, got %d", len(vectors))
+	}
+
+	again, _ := e.Embed(context.Background(), texts[:1])
+	if !reflect.DeepEqual(again[0], vectors[0]) {
+		t.Error("Expected embeddings to be deterministic")
+	}
+
+	if similarity := Cosine(vectors[0], vectors[0]); similarity < 0.999 || similarity > 1.001 {
+		t.Errorf("Expected vectors to be normalized, got a self similarity of %f", similarity)
+	}
+
+	related, unrelated := Cosine(vectors[0], vectors[1]), Cosine(vectors[0], vectors[2])
+	if related <= unrelated {
+		t.Errorf("Expected related texts to be more similar (%f) than unrelated ones (%f)", related, unrelated)
+	}
+}
+
+func TestOpenAIEmbedder(t *testing.T) {
+	var requests []embeddingRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" || r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, `{"error": {"message": "unauthorized"}}`, http.StatusUnauthorized)
+			return
+		}
+
+		req := embeddingRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		requests = append(requests, req)
+
+		// answer in reverse order, with the length of each text as its vector
+		resp := embeddingResponse{}
+		for i := len(req.Input) - 1; i >= 0; i-- {
+			resp.Data = append(resp.Data, struct {
+				Index     int       `json:"index"`
+				Embedding []float32 `json:"embedding"`
+			}{i, []float32{float32(len(req.Input[i])), 0}})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	t.Setenv("TEST_EMBEDDING_KEY", "secret")
+	e, err := New(&pb.EmbeddingConfig{Provider: "openai", Model: "local-model", BaseUrl: server.URL + "/v1/", ApiKeyEnv: "TEST_EMBEDDING_KEY", BatchSize: 2})
+	if err != nil {
+		t.Fatalf("New() returned unexpected error: %v", err)
+	}
+
+	vectors, err := e.Embed(context.Background(), []string{"a", "bb", "ccc"})
+	if err != nil {
+		t.Fatalf("Embed() returned unexpected error: %v", err)
+	}
+
+	expected := [][]float32{{1, 0}, {2, 0}, {3, 0}}
+	if !reflect.DeepEqual(vectors, expected) {
+		t.Errorf("Embed() = %v, want %v", vectors, expected)
+	}
+	if len(requests) != 2 || requests[0].Model != "local-model" || len(requests[1].Input) != 1 {
+		t.Errorf("Expected 2 batched requests, got %v", requests)
+	}
+	if e.Dimension() != 2 {
+		t.Errorf("Expected the dimension to be learned from the response, got %d", e.Dimension())
+	}
+
+	t.Setenv("TEST_EMBEDDING_KEY", "wrong")
+	e, _ = New(&pb.EmbeddingConfig{Provider: "openai", Model: "local-model", BaseUrl: server.URL + "/v1", ApiKeyEnv: "TEST_EMBEDDING_KEY"})
+	if _, err := e.Embed(context.Background(), []string{"a"}); err == nil || !strings.Contains(err.Error(), "unauthorized") {
+		t.Errorf("Expected the API error message, got %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package embedder
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/kljensen/snowball/english"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+const DefaultHashDimensions = 256
+
+var wordRegex = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// hashEmbedder embeds texts without a model: each stemmed term and pair of adjacent terms is hashed to a dimension
+// and a sign, which amounts to a sparse random projection of the term frequencies. Term frequencies are dampened
+// logarithmically and stop words are skipped, standing in for inverse document frequencies. Texts sharing
+// vocabulary get similar vectors, which is enough for tests and air-gapped use but knows nothing of synonyms.
+type hashEmbedder struct {
+	dimensions int
+}
+
+func newHashEmbedder(config *pb.EmbeddingConfig) (Embedder, error) {
+	dimensions := int(config.GetDimensions())
+	if dimensions == 0 {
+		dimensions = DefaultHashDimensions
+	}
+	if dimensions < 0 {
+		return nil, fmt.Errorf("invalid number of dimensions %d", dimensions)
+	}
+	if model := config.GetModel(); model != "" && model != hashModel(dimensions) {
+		return nil, fmt.Errorf("the hash provider only supports model %s, got %s", hashModel(dimensions), model)
+	}
+
+	return &hashEmbedder{dimensions: dimensions}, nil
+}
+
+// NewHashEmbedder returns the offline embedder with the given number of dimensions.
+func NewHashEmbedder(dimensions int) Embedder {
+	return &hashEmbedder{dimensions: dimensions}
+}
+
+func hashModel(dimensions int) string {
+	return fmt.Sprintf("hash-%d", dimensions)
+}
+
+func (h *hashEmbedder) Dimension() int {
+	return h.dimensions
+}
+
+func (h *hashEmbedder) Model() string {
+	return hashModel(h.dimensions)
+}
+
+func (h *hashEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		vectors[i] = h.embed(text)
+	}
+	return vectors, nil
+}
+
+func (h *hashEmbedder) embed(text string) []float32 {
+	frequencies := make(map[string]int)
+	previous := ""
+	for _, word := range wordRegex.FindAllString(strings.ToLower(text), -1) {
+		if english.IsStopWord(word) {
+			continue
+		}
+		term := english.Stem(word, false)
+		frequencies[term]++
+		if previous != "" {
+			frequencies[previous+" "+term]++
+		}
+		previous = term
+	}
+
+	vector := make([]float32, h.dimensions)
+	for term, count := range frequencies {
+		hasher := fnv.New64a()
+		hasher.Write([]byte(term))
+		sum := hasher.Sum64()
+
+		weight := float32(1 + math.Log(float64(count)))
+		if sum>>63 == 1 {
+			weight = -weight
+		}
+		vector[sum%uint64(h.dimensions)] += weight
+	}
+
+	Normalize(vector)
+	return vector
+}
@@ -0,0 +1,181 @@
+package embedder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+const (
+	DefaultBaseURL   = "https://api.openai.com/v1"
+	DefaultAPIKeyEnv = "OPENAI_API_KEY"
+	DefaultBatchSize = 64
+)
+
+// openAIEmbedder is a client of the OpenAI /v1/embeddings API, which is also served by local model servers.
+type openAIEmbedder struct {
+	client    *http.Client
+	baseURL   string
+	apiKey    string
+	model     string
+	batchSize int
+	// dimensions requested from the model, only sent when configured since not all models support it
+	requested int
+
+	// dimensions is learned from the first response unless configured, so Embed may be called concurrently
+	mu         sync.Mutex
+	dimensions int
+}
+
+type embeddingRequest struct {
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Dimensions int      `json:"dimensions,omitempty"`
+}
+
+type embeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+type apiErrorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func newOpenAIEmbedder(config *pb.EmbeddingConfig) (Embedder, error) {
+	if config.GetModel() == "" {
+		return nil, fmt.Errorf("the openai provider requires a model")
+	}
+
+	baseURL := config.GetBaseUrl()
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	apiKeyEnv := config.GetApiKeyEnv()
+	if apiKeyEnv == "" {
+		apiKeyEnv = DefaultAPIKeyEnv
+	}
+
+	batchSize := int(config.GetBatchSize())
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	return &openAIEmbedder{
+		client:     &http.Client{Timeout: 2 * time.Minute},
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		apiKey:     os.Getenv(apiKeyEnv),
+		model:      config.GetModel(),
+		batchSize:  batchSize,
+		requested:  int(config.GetDimensions()),
+		dimensions: int(config.GetDimensions()),
+	}, nil
+}
+
+func (o *openAIEmbedder) Dimension() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.dimensions
+}
+
+func (o *openAIEmbedder) Model() string {
+	return o.model
+}
+
+// Embed sends the texts in batches of at most batchSize. The dimension is learned from the first response
+// if it was not configured, and every vector must have it.
+func (o *openAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += o.batchSize {
+		batch := texts[start:min(start+o.batchSize, len(texts))]
+
+		embeddings, err := o.embedBatch(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+
+		o.mu.Lock()
+		for _, embedding := range embeddings {
+			if o.dimensions == 0 {
+				o.dimensions = len(embedding)
+			}
+			if len(embedding) != o.dimensions {
+				o.mu.Unlock()
+				return nil, fmt.Errorf("model %s returned a vector of %d dimensions, expected %d", o.model, len(embedding), o.dimensions)
+			}
+			vectors = append(vectors, embedding)
+		}
+		o.mu.Unlock()
+	}
+
+	return vectors, nil
+}
+
+func (o *openAIEmbedder) embedBatch(ctx context.Context, batch []string) ([][]float32, error) {
+	body, err := json.Marshal(&embeddingRequest{Model: o.model, Input: batch, Dimensions: o.requested})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal embedding request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", o.baseURL+"/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if o.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+o.apiKey)
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request embeddings: %v", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedding response: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &apiErrorResponse{}
+		if json.Unmarshal(data, apiErr) == nil && apiErr.Error.Message != "" {
+			return nil, fmt.Errorf("embedding API returned %s: %s", resp.Status, apiErr.Error.Message)
+		}
+		return nil, fmt.Errorf("embedding API returned %s", resp.Status)
+	}
+
+	parsed := &embeddingResponse{}
+	if err := json.Unmarshal(data, parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse embedding response: %v", err)
+	}
+
+	if len(parsed.Data) != len(batch) {
+		return nil, fmt.Errorf("embedding API returned %d vectors for %d texts", len(parsed.Data), len(batch))
+	}
+
+	// vectors are matched to texts by index, since the API does not promise to keep their order
+	embeddings := make([][]float32, len(batch))
+	for _, d := range parsed.Data {
+		if d.Index < 0 || d.Index >= len(batch) || embeddings[d.Index] != nil {
+			return nil, fmt.Errorf("embedding API returned an invalid index %d", d.Index)
+		}
+		embeddings[d.Index] = d.Embedding
+	}
+
+	return embeddings, nil
+}
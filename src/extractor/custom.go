@@ -0,0 +1,152 @@
+package extractor
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+// Extract option that sets how content of a custom type is encoded: "binary", "json" or "text".
+// By default, content starting with "{" is decoded as JSON and anything else as binary.
+const customEncodingOption = "encoding"
+
+var (
+	customTypesMu sync.RWMutex
+	customTypes   = make(map[string]protoreflect.MessageDescriptor)
+)
+
+// RegisterType makes a user defined type available to Extract for content with the CUSTOM DataType.
+// Registering a name again replaces its message descriptor.
+func RegisterType(name string, descriptor protoreflect.MessageDescriptor) {
+	customTypesMu.Lock()
+	defer customTypesMu.Unlock()
+	customTypes[name] = descriptor
+}
+
+func lookupType(name string) (protoreflect.MessageDescriptor, bool) {
+	customTypesMu.RLock()
+	defer customTypesMu.RUnlock()
+	descriptor, ok := customTypes[name]
+	return descriptor, ok
+}
+
+// extractCustom decodes content as a message of a registered type and renders it field by field,
+// as key-path/value pairs like the structured data types, eg. "address.city: Lisbon".
+func extractCustom(typeName string, content []byte, options map[string]string) ([]*pb.Section, error) {
+	descriptor, ok := lookupType(typeName)
+	if !ok {
+		return nil, fmt.Errorf("type %s is not registered", typeName)
+	}
+
+	msg := dynamicpb.NewMessage(descriptor)
+
+	encoding := options[customEncodingOption]
+	if encoding == "" {
+		encoding = "binary"
+		if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '{' {
+			encoding = "json"
+		}
+	}
+
+	var err error
+	switch encoding {
+	case "binary":
+		err = proto.Unmarshal(content, msg)
+	case "json":
+		err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(content, msg)
+	case "text":
+		err = prototext.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(content, msg)
+	default:
+		return nil, fmt.Errorf("unknown encoding %s, expected binary, json or text", encoding)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s message: %w", descriptor.FullName(), err)
+	}
+
+	doc := newFlatDocument()
+	flattenMessage(doc, "", msg)
+	if len(doc.lines) == 0 {
+		return nil, nil
+	}
+
+	return []*pb.Section{{
+		Path: typeName,
+		Kind: "message",
+		Text: string(descriptor.FullName()) + "\n" + strings.Join(doc.lines, "\n"),
+		Attributes: map[string]string{
+			"message": string(descriptor.FullName()),
+		},
+		Terms: doc.terms,
+	}}, nil
+}
+
+// flattenMessage renders the set fields of a message in declaration order.
+func flattenMessage(doc *flatDocument, path string, msg protoreflect.Message) {
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if !msg.Has(field) {
+			continue
+		}
+		fieldPath := keyPath(path, string(field.Name()))
+		value := msg.Get(field)
+
+		switch {
+		case field.IsList():
+			list := value.List()
+			doc.addTerm(fieldPath)
+			for j := 0; j < list.Len(); j++ {
+				flattenValue(doc, indexPath(fieldPath, j), field, list.Get(j))
+			}
+
+		case field.IsMap():
+			doc.addTerm(fieldPath)
+			m := value.Map()
+			var keys []protoreflect.MapKey
+			m.Range(func(key protoreflect.MapKey, _ protoreflect.Value) bool {
+				keys = append(keys, key)
+				return true
+			})
+			sort.Slice(keys, func(a, b int) bool {
+				return keys[a].String() < keys[b].String()
+			})
+			for _, key := range keys {
+				flattenValue(doc, fmt.Sprintf("%s[%q]", fieldPath, key.String()), field.MapValue(), m.Get(key))
+			}
+
+		default:
+			flattenValue(doc, fieldPath, field, value)
+		}
+	}
+}
+
+func flattenValue(doc *flatDocument, path string, field protoreflect.FieldDescriptor, value protoreflect.Value) {
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		flattenMessage(doc, path, value.Message())
+
+	case protoreflect.EnumKind:
+		name := fmt.Sprint(value.Enum())
+		if enumValue := field.Enum().Values().ByNumber(value.Enum()); enumValue != nil {
+			name = string(enumValue.Name())
+		}
+		doc.addValue(path, name)
+
+	case protoreflect.BytesKind:
+		doc.addValue(path, base64.StdEncoding.EncodeToString(value.Bytes()))
+
+	default:
+		doc.addValue(path, value.String())
+	}
+}
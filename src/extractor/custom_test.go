@@ -0,0 +1,69 @@
+package extractor
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+func TestExtractCustom(t *testing.T) {
+	RegisterType("Entry", (&pb.IndexListEntry{}).ProtoReflect().Descriptor())
+
+	entry := &pb.IndexListEntry{
+		Name: "README.md",
+		ContentMetadata: &pb.ContentMetadata{
+			URI:      "/docs/README.md",
+			DataType: pb.DataType_MARKDOWN,
+		},
+		WordOccurrences: map[string]int32{"search": 2, "index": 1},
+		Sections: []*pb.Section{
+			{Path: "Usage", Kind: "heading"},
+		},
+	}
+
+	expected := []string{
+		"semantifly.IndexListEntry",
+		"name: README.md",
+		"content_metadata.URI: /docs/README.md",
+		"content_metadata.data_type: MARKDOWN",
+		`word_occurrences["index"]: 1`,
+		`word_occurrences["search"]: 2`,
+		"sections[0].path: Usage",
+		"sections[0].kind: heading",
+	}
+
+	binary, err := proto.Marshal(entry)
+	if err != nil {
+		t.Fatalf("Failed to marshal message: %v", err)
+	}
+	json, err := protojson.Marshal(entry)
+	if err != nil {
+		t.Fatalf("Failed to marshal message: %v", err)
+	}
+
+	for name, content := range map[string][]byte{"binary": binary, "json": json} {
+		sections, err := Extract(&pb.ContentMetadata{DataType: pb.DataType_CUSTOM, TypeName: "Entry"}, content)
+		if err != nil {
+			t.Fatalf("Extract() returned unexpected error for %s content: %v", name, err)
+		}
+
+		if len(sections) != 1 {
+			t.Fatalf("Expected 1 section for %s content, got %d", name, len(sections))
+		}
+
+		if sections[0].Text != strings.Join(expected, "\n") {
+			t.Errorf("Unexpected text for %s content:\n%s\nwant:\n%s", name, sections[0].Text, strings.Join(expected, "\n"))
+		}
+	}
+}
+
+func TestExtractCustom_Unregistered(t *testing.T) {
+	_, err := Extract(&pb.ContentMetadata{DataType: pb.DataType_CUSTOM, TypeName: "Missing"}, []byte("{}"))
+	if err == nil {
+		t.Error("Expected an error for an unregistered type, but got nil")
+	}
+}
@@ -0,0 +1,180 @@
+package extractor
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+var extensionDataTypes = map[string]pb.DataType{
+	".txt":      pb.DataType_TEXT,
+	".proto":    pb.DataType_PROTO,
+	".md":       pb.DataType_MARKDOWN,
+	".markdown": pb.DataType_MARKDOWN,
+	".ipynb":    pb.DataType_IPYNB,
+	".pdf":      pb.DataType_PDF,
+	".json":     pb.DataType_JSON,
+	".yaml":     pb.DataType_YAML,
+	".yml":      pb.DataType_YAML,
+	".toml":     pb.DataType_TOML,
+}
+
+var mimeDataTypes = map[string]pb.DataType{
+	"text/plain":                       pb.DataType_TEXT,
+	"text/markdown":                    pb.DataType_MARKDOWN,
+	"text/x-markdown":                  pb.DataType_MARKDOWN,
+	"application/pdf":                  pb.DataType_PDF,
+	"application/json":                 pb.DataType_JSON,
+	"application/x-ipynb+json":         pb.DataType_IPYNB,
+	"application/vnd.oai.openapi":      pb.DataType_OPENAPI,
+	"application/vnd.oai.openapi+json": pb.DataType_OPENAPI,
+	"application/yaml":                 pb.DataType_YAML,
+	"application/x-yaml":               pb.DataType_YAML,
+	"text/yaml":                        pb.DataType_YAML,
+	"text/x-yaml":                      pb.DataType_YAML,
+	"application/toml":                 pb.DataType_TOML,
+}
+
+// Extensions returns the file extensions that are detected as the DataType, in order.
+func Extensions(dataType pb.DataType) []string {
+	var extensions []string
+	for ext, t := range extensionDataTypes {
+		if t == dataType {
+			extensions = append(extensions, ext)
+		}
+	}
+	sort.Strings(extensions)
+	return extensions
+}
+
+// DetectDataType chooses the DataType of the content at uri, and returns a description of how it was chosen.
+// In order of precedence, it uses the overrides (see pb.Config), the extension of the file name,
+// the content type reported by the source and finally sniffing of the content itself. fetch is only
+// called when the name of the file is not enough to decide, and returns the content and its content type.
+func DetectDataType(uri string, overrides map[string]pb.DataType, fetch func() ([]byte, string, error)) (pb.DataType, string, error) {
+	name := uriFileName(uri)
+	ext := strings.ToLower(path.Ext(name))
+
+	patterns := make([]string, 0, len(overrides))
+	for pattern := range overrides {
+		if !strings.HasPrefix(pattern, ".") && !strings.Contains(pattern, "/") {
+			patterns = append(patterns, pattern)
+		}
+	}
+	sort.Strings(patterns)
+
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return overrides[pattern], "config " + pattern, nil
+		}
+	}
+
+	if dataType, ok := overrides[ext]; ok && ext != "" {
+		return dataType, "config " + ext, nil
+	}
+
+	if dataType, ok := extensionDataTypes[ext]; ok {
+		// OpenAPI specifications are plain JSON or YAML files, but are conventionally named as such
+		lowerName := strings.ToLower(name)
+		if (dataType == pb.DataType_JSON || dataType == pb.DataType_YAML) && (strings.Contains(lowerName, "openapi") || strings.Contains(lowerName, "swagger")) {
+			return pb.DataType_OPENAPI, "file name " + name, nil
+		}
+		return dataType, "extension " + ext, nil
+	}
+
+	content, contentType, err := fetch()
+	if err != nil {
+		return pb.DataType_TEXT, "", err
+	}
+
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		if dataType, ok := overrides[mediaType]; ok {
+			return dataType, "config " + mediaType, nil
+		}
+		if dataType, ok := mimeDataTypes[mediaType]; ok {
+			// a generic type can be narrowed down by the content, eg. a JSON notebook
+			if sniffed, ok := sniffDataType(content); ok && refines(dataType, sniffed) {
+				return sniffed, "content type " + mediaType + " and content", nil
+			}
+			return dataType, "content type " + mediaType, nil
+		}
+	}
+
+	if dataType, ok := sniffDataType(content); ok {
+		return dataType, "content", nil
+	}
+
+	return pb.DataType_TEXT, "default", nil
+}
+
+// refines reports whether sniffed is a more specific kind of content than dataType.
+func refines(dataType, sniffed pb.DataType) bool {
+	switch dataType {
+	case pb.DataType_TEXT:
+		return sniffed != pb.DataType_PDF
+	case pb.DataType_JSON, pb.DataType_YAML:
+		return sniffed == pb.DataType_OPENAPI || sniffed == pb.DataType_IPYNB
+	}
+	return false
+}
+
+// sniffDataType recognizes content from its first bytes, like http.DetectContentType, and from the
+// structure of JSON documents. YAML is only recognized from its usual opening lines, and markdown not at all,
+// since "# " also starts comments in many languages.
+func sniffDataType(content []byte) (pb.DataType, bool) {
+	if http.DetectContentType(content) == "application/pdf" {
+		return pb.DataType_PDF, true
+	}
+
+	trimmed := bytes.TrimSpace(content)
+	if len(trimmed) == 0 {
+		return pb.DataType_TEXT, false
+	}
+
+	if trimmed[0] == '{' || trimmed[0] == '[' {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(trimmed, &fields); err == nil {
+			if fields["nbformat"] != nil && (fields["cells"] != nil || fields["worksheets"] != nil) {
+				return pb.DataType_IPYNB, true
+			}
+			if fields["openapi"] != nil || fields["swagger"] != nil {
+				return pb.DataType_OPENAPI, true
+			}
+			return pb.DataType_JSON, true
+		}
+		if json.Valid(trimmed) {
+			return pb.DataType_JSON, true
+		}
+	}
+
+	firstLine, _, _ := bytes.Cut(trimmed, []byte("\n"))
+	firstLine = bytes.TrimSpace(firstLine)
+
+	switch {
+	case bytes.HasPrefix(firstLine, []byte("syntax")) && bytes.Contains(firstLine, []byte("proto")):
+		return pb.DataType_PROTO, true
+
+	case bytes.HasPrefix(firstLine, []byte("openapi:")) || bytes.HasPrefix(firstLine, []byte("swagger:")):
+		return pb.DataType_OPENAPI, true
+
+	case bytes.Equal(firstLine, []byte("---")) || bytes.HasPrefix(firstLine, []byte("%YAML")):
+		return pb.DataType_YAML, true
+	}
+
+	return pb.DataType_TEXT, false
+}
+
+// uriFileName returns the last element of the path of a file, archive member or URL, without any query.
+func uriFileName(uri string) string {
+	if u, err := url.Parse(uri); err == nil && u.Scheme != "" && u.Host != "" {
+		uri = u.Path
+	}
+	return path.Base(uri)
+}
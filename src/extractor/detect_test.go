@@ -0,0 +1,68 @@
+package extractor
+
+import (
+	"errors"
+	"testing"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+func TestDetectDataType(t *testing.T) {
+	tests := []struct {
+		name        string
+		uri         string
+		overrides   map[string]pb.DataType
+		content     string
+		contentType string
+		expected    pb.DataType
+		detectedBy  string
+	}{
+		{"extension", "/docs/README.md", nil, "", "", pb.DataType_MARKDOWN, "extension .md"},
+		{"extension case", "/docs/Guide.PDF", nil, "", "", pb.DataType_PDF, "extension .pdf"},
+		{"archive member", "/sdk/bundle.zip!/config/app.yml", nil, "", "", pb.DataType_YAML, "extension .yml"},
+		{"openapi file name", "/api/openapi.yaml", nil, "", "", pb.DataType_OPENAPI, "file name openapi.yaml"},
+		{"url path", "https://example.com/schema.proto?raw=1", nil, "", "", pb.DataType_PROTO, "extension .proto"},
+		{"override extension", "/etc/app.conf", map[string]pb.DataType{".conf": pb.DataType_TOML}, "", "", pb.DataType_TOML, "config .conf"},
+		{"override file name", "/repo/Pipfile", map[string]pb.DataType{"Pipfile": pb.DataType_TOML}, "", "", pb.DataType_TOML, "config Pipfile"},
+		{"override beats extension", "/repo/data.json", map[string]pb.DataType{".json": pb.DataType_TEXT}, "", "", pb.DataType_TEXT, "config .json"},
+		{"content type", "https://example.com/docs", nil, "# Title", "text/markdown; charset=utf-8", pb.DataType_MARKDOWN, "content type text/markdown"},
+		{"override content type", "https://example.com/api", map[string]pb.DataType{"application/vnd.api+json": pb.DataType_JSON}, "{}", "application/vnd.api+json", pb.DataType_JSON, "config application/vnd.api+json"},
+		{"content type refined", "https://example.com/spec", nil, `{"openapi": "3.0.0"}`, "application/json", pb.DataType_OPENAPI, "content type application/json and content"},
+		{"sniffed pdf", "/docs/manual", nil, "%PDF-1.4\n...", "", pb.DataType_PDF, "content"},
+		{"sniffed notebook", "/notebooks/analysis", nil, `{"nbformat": 4, "cells": []}`, "", pb.DataType_IPYNB, "content"},
+		{"sniffed json", "/data/export", nil, `[1, 2, 3]`, "", pb.DataType_JSON, "content"},
+		{"sniffed proto", "/schemas/user", nil, "syntax = \"proto3\";\n", "", pb.DataType_PROTO, "content"},
+		{"sniffed yaml", "/deploy/manifest", nil, "---\nkind: Service\n", "", pb.DataType_YAML, "content"},
+		{"python comment", "/scripts/run.py", nil, "# not a heading\nprint('hi')\n", "", pb.DataType_TEXT, "default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetch := func() ([]byte, string, error) {
+				return []byte(tt.content), tt.contentType, nil
+			}
+
+			dataType, detectedBy, err := DetectDataType(tt.uri, tt.overrides, fetch)
+			if err != nil {
+				t.Fatalf("DetectDataType() returned unexpected error: %v", err)
+			}
+			if dataType != tt.expected || detectedBy != tt.detectedBy {
+				t.Errorf("DetectDataType() = %s, %q, want %s, %q", dataType, detectedBy, tt.expected, tt.detectedBy)
+			}
+		})
+	}
+}
+
+func TestDetectDataType_FetchOnlyWhenNeeded(t *testing.T) {
+	fetch := func() ([]byte, string, error) {
+		return nil, "", errors.New("unreachable")
+	}
+
+	if _, _, err := DetectDataType("/docs/README.md", nil, fetch); err != nil {
+		t.Errorf("Expected no fetch for a known extension, got: %v", err)
+	}
+
+	if _, _, err := DetectDataType("/docs/README", nil, fetch); err == nil {
+		t.Error("Expected the fetch error to be returned, but got nil")
+	}
+}
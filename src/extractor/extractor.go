@@ -0,0 +1,113 @@
+package extractor
+
+import (
+	"fmt"
+	"strings"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+type ExtractorInfo struct {
+	Description string
+	Extract     func(content []byte, options map[string]string) ([]*pb.Section, error)
+}
+
+var extractorDict = map[pb.DataType]ExtractorInfo{
+	pb.DataType_PROTO: {
+		Description: "Protobuf schema: one section per message, enum, service and rpc",
+		Extract:     extractProto,
+	},
+	pb.DataType_OPENAPI: {
+		Description: "OpenAPI 3 / Swagger 2 specification in JSON or YAML: one section per operation",
+		Extract:     extractOpenAPI,
+	},
+	pb.DataType_MARKDOWN: {
+		Description: "Markdown document: one section per heading, with formatting stripped",
+		Extract:     extractMarkdown,
+	},
+	pb.DataType_IPYNB: {
+		Description: "Jupyter notebook: one section per cell, optionally with text outputs (extract option outputs=true)",
+		Extract:     extractIPYNB,
+	},
+	pb.DataType_PDF: {
+		Description: "PDF document: one section with the extracted text of each page",
+		Extract:     extractPDF,
+	},
+	pb.DataType_JSON: {
+		Description: "JSON document: one section of key-path/value pairs, eg. spec.containers[0].image, with key paths searchable",
+		Extract:     extractJSON,
+	},
+	pb.DataType_YAML: {
+		Description: "YAML document: one section of key-path/value pairs, with key paths searchable; streams of several documents are added as one entry per document",
+		Extract:     extractYAML,
+	},
+	pb.DataType_TOML: {
+		Description: "TOML document: one section of key-path/value pairs, with key paths searchable",
+		Extract:     extractTOML,
+	},
+}
+
+// Description describes what the extractor of a DataType produces. Data types without an extractor are indexed as is.
+func Description(dataType pb.DataType) string {
+	if dataType == pb.DataType_CUSTOM {
+		return "User defined type: the decoded message rendered field by field as key-path/value pairs"
+	}
+	if info, ok := extractorDict[dataType]; ok {
+		return info.Description
+	}
+	return "Plain text, indexed as is"
+}
+
+// HasSections reports whether content of a DataType is split into Sections by an extractor.
+func HasSections(dataType pb.DataType) bool {
+	_, ok := extractorDict[dataType]
+	return ok || dataType == pb.DataType_CUSTOM
+}
+
+// Extract splits content into Sections according to the DataType and extract options of its metadata.
+// Data types without a registered extractor, such as TEXT, have no sections and return nil.
+// CUSTOM content is decoded as the user defined type named by the metadata, see RegisterType.
+func Extract(metadata *pb.ContentMetadata, content []byte) ([]*pb.Section, error) {
+	if metadata.GetDataType() == pb.DataType_CUSTOM {
+		sections, err := extractCustom(metadata.GetTypeName(), content, metadata.GetExtractOptions())
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract %s content: %w", metadata.GetTypeName(), err)
+		}
+		return sections, nil
+	}
+
+	info, ok := extractorDict[metadata.GetDataType()]
+	if !ok {
+		return nil, nil
+	}
+
+	sections, err := info.Extract(content, metadata.GetExtractOptions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract %s content: %w", metadata.GetDataType(), err)
+	}
+
+	return sections, nil
+}
+
+// Text returns the plain text of content that should be indexed according to its metadata.
+func Text(metadata *pb.ContentMetadata, content []byte) (string, error) {
+	sections, err := Extract(metadata, content)
+	if err != nil {
+		return "", err
+	}
+
+	if sections == nil {
+		return string(content), nil
+	}
+
+	return JoinSections(sections), nil
+}
+
+// JoinSections concatenates the text of all sections, separated by blank lines.
+func JoinSections(sections []*pb.Section) string {
+	texts := make([]string, 0, len(sections))
+	for _, s := range sections {
+		texts = append(texts, s.Text)
+	}
+	return strings.Join(texts, "\n\n")
+}
@@ -0,0 +1,158 @@
+package extractor
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+// Extract option that includes the text outputs of code cells, eg. printed values and results.
+const notebookOutputsOption = "outputs"
+
+type notebook struct {
+	Cells      []notebookCell `json:"cells"`
+	Worksheets []struct {
+		Cells []notebookCell `json:"cells"`
+	} `json:"worksheets"`
+	Metadata struct {
+		KernelSpec struct {
+			Language string `json:"language"`
+		} `json:"kernelspec"`
+		LanguageInfo struct {
+			Name string `json:"name"`
+		} `json:"language_info"`
+	} `json:"metadata"`
+}
+
+type notebookCell struct {
+	CellType       string           `json:"cell_type"`
+	Source         multilineString  `json:"source"`
+	Input          multilineString  `json:"input"`
+	ExecutionCount *int             `json:"execution_count"`
+	Outputs        []notebookOutput `json:"outputs"`
+}
+
+type notebookOutput struct {
+	OutputType string                     `json:"output_type"`
+	Text       multilineString            `json:"text"`
+	Data       map[string]json.RawMessage `json:"data"`
+	EName      string                     `json:"ename"`
+	EValue     string                     `json:"evalue"`
+}
+
+// multilineString is a notebook string, which may be stored either as a string or as a list of lines.
+type multilineString string
+
+func (m *multilineString) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*m = multilineString(s)
+		return nil
+	}
+
+	var lines []string
+	if err := json.Unmarshal(data, &lines); err != nil {
+		return fmt.Errorf("expected a string or list of strings: %w", err)
+	}
+	*m = multilineString(strings.Join(lines, ""))
+	return nil
+}
+
+// extractIPYNB produces a section for each markdown, code and raw cell of a Jupyter notebook, in order.
+// Markdown formatting is stripped, and base64 encoded outputs such as images are never indexed.
+// With the "outputs" extract option set to "true", the text outputs of code cells are included as well.
+func extractIPYNB(content []byte, options map[string]string) ([]*pb.Section, error) {
+	var nb notebook
+	if err := json.Unmarshal(content, &nb); err != nil {
+		return nil, fmt.Errorf("failed to parse notebook: %w", err)
+	}
+
+	// nbformat 3 and earlier stored cells within worksheets
+	cells := nb.Cells
+	for _, ws := range nb.Worksheets {
+		cells = append(cells, ws.Cells...)
+	}
+
+	language := nb.Metadata.LanguageInfo.Name
+	if language == "" {
+		language = nb.Metadata.KernelSpec.Language
+	}
+
+	includeOutputs := options[notebookOutputsOption] == "true"
+
+	var sections []*pb.Section
+	for i, cell := range cells {
+		attributes := map[string]string{
+			"cell": fmt.Sprint(i),
+		}
+
+		var text string
+		switch cell.CellType {
+		case "markdown":
+			text = stripMarkdownBlock(strings.Split(string(cell.Source), "\n"))
+
+		case "code":
+			text = string(cell.Source)
+			if text == "" {
+				text = string(cell.Input)
+			}
+			if language != "" {
+				attributes["language"] = language
+			}
+			if cell.ExecutionCount != nil {
+				attributes["execution_count"] = fmt.Sprint(*cell.ExecutionCount)
+			}
+			if includeOutputs {
+				if outputs := notebookOutputText(cell.Outputs); outputs != "" {
+					text = strings.TrimRight(text, "\n") + "\nOutput:\n" + outputs
+				}
+			}
+
+		default:
+			text = string(cell.Source)
+		}
+
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+
+		sections = append(sections, &pb.Section{
+			Path:       fmt.Sprintf("cell[%d]", i),
+			Kind:       cell.CellType,
+			Text:       text,
+			Attributes: attributes,
+		})
+	}
+
+	return sections, nil
+}
+
+// notebookOutputText returns the text of a code cell's outputs, skipping any that are not plain text.
+func notebookOutputText(outputs []notebookOutput) string {
+	var texts []string
+	for _, output := range outputs {
+		switch output.OutputType {
+		case "stream":
+			texts = append(texts, string(output.Text))
+
+		case "execute_result", "display_data", "pyout":
+			var plain multilineString
+			if raw, ok := output.Data["text/plain"]; ok && json.Unmarshal(raw, &plain) == nil {
+				texts = append(texts, string(plain))
+			} else if output.Text != "" {
+				texts = append(texts, string(output.Text))
+			}
+
+		case "error", "pyerr":
+			texts = append(texts, fmt.Sprintf("%s: %s", output.EName, output.EValue))
+		}
+	}
+
+	for i, text := range texts {
+		texts[i] = strings.TrimRight(text, "\n")
+	}
+	return strings.TrimSpace(strings.Join(texts, "\n"))
+}
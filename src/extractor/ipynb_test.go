@@ -0,0 +1,81 @@
+package extractor
+
+import (
+	"strings"
+	"testing"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+const testNotebook = `{
+  "metadata": {"language_info": {"name": "python"}},
+  "nbformat": 4,
+  "cells": [
+    {"cell_type": "markdown", "source": ["# Loading **users**\n", "From the [db](http://db)."]},
+    {"cell_type": "code", "execution_count": 3, "source": "df = load_users()\ndf.head()",
+     "outputs": [
+       {"output_type": "stream", "name": "stdout", "text": ["loaded 10 rows\n"]},
+       {"output_type": "execute_result", "data": {"text/plain": ["   id  name\n", "0   1  ada"], "image/png": "iVBORw0KGgo="}},
+       {"output_type": "display_data", "data": {"image/png": "iVBORw0KGgo="}}
+     ]},
+    {"cell_type": "code", "execution_count": null, "source": [], "outputs": []},
+    {"cell_type": "raw", "source": "raw text"}
+  ]
+}`
+
+func TestExtractIPYNB(t *testing.T) {
+	sections, err := Extract(&pb.ContentMetadata{DataType: pb.DataType_IPYNB}, []byte(testNotebook))
+	if err != nil {
+		t.Fatalf("Extract() returned unexpected error: %v", err)
+	}
+
+	if len(sections) != 3 {
+		t.Fatalf("Expected 3 sections, got %d: %v", len(sections), sections)
+	}
+
+	markdown, code, raw := sections[0], sections[1], sections[2]
+
+	if markdown.Path != "cell[0]" || markdown.Kind != "markdown" || markdown.Text != "Loading users\nFrom the db." {
+		t.Errorf("Unexpected markdown cell section: %v", markdown)
+	}
+
+	if code.Path != "cell[1]" || code.Kind != "code" || code.Text != "df = load_users()\ndf.head()" {
+		t.Errorf("Unexpected code cell section: %v", code)
+	}
+	if code.Attributes["cell"] != "1" || code.Attributes["language"] != "python" || code.Attributes["execution_count"] != "3" {
+		t.Errorf("Unexpected code cell attributes: %v", code.Attributes)
+	}
+
+	// the empty code cell is skipped, but cell indices still refer to the notebook
+	if raw.Path != "cell[3]" || raw.Kind != "raw" {
+		t.Errorf("Unexpected raw cell section: %v", raw)
+	}
+}
+
+func TestExtractIPYNB_Outputs(t *testing.T) {
+	metadata := &pb.ContentMetadata{
+		DataType:       pb.DataType_IPYNB,
+		ExtractOptions: map[string]string{"outputs": "true"},
+	}
+
+	sections, err := Extract(metadata, []byte(testNotebook))
+	if err != nil {
+		t.Fatalf("Extract() returned unexpected error: %v", err)
+	}
+
+	expected := "df = load_users()\ndf.head()\nOutput:\nloaded 10 rows\n   id  name\n0   1  ada"
+	if sections[1].Text != expected {
+		t.Errorf("Expected code cell text:\n%s\ngot:\n%s", expected, sections[1].Text)
+	}
+
+	if strings.Contains(sections[1].Text, "iVBORw0KGgo") {
+		t.Errorf("Expected image outputs not to be indexed")
+	}
+}
+
+func TestExtractIPYNB_Invalid(t *testing.T) {
+	_, err := Extract(&pb.ContentMetadata{DataType: pb.DataType_IPYNB}, []byte(`{"cells": "not a list"}`))
+	if err == nil {
+		t.Error("Expected an error for an invalid notebook, but got nil")
+	}
+}
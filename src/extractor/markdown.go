@@ -0,0 +1,254 @@
+package extractor
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+// Separator between the headings of a markdown section path, eg. "Intended Usage > RAG".
+const headingPathSeparator = " > "
+
+// Path of the content that comes before the first heading of a markdown document.
+const preamblePath = "(preamble)"
+
+var (
+	atxHeadingRegex    = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	setextHeadingRegex = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	fenceRegex         = regexp.MustCompile("^ {0,3}(```+|~~~+)")
+	imageRegex         = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	linkRegex          = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	refLinkRegex       = regexp.MustCompile(`\[([^\]]*)\]\[[^\]]*\]`)
+	linkDefRegex       = regexp.MustCompile(`^ {0,3}\[[^\]]+\]:\s+\S+`)
+	htmlTagRegex       = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+	emphasisRegex      = regexp.MustCompile(`(\*\*|__|\*|_|~~)([^\s*_~](?:.*?[^\s*_~])?)(\*\*|__|\*|_|~~)`)
+	listMarkerRegex    = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+(?:\[[ xX]\]\s+)?`)
+	blockquoteRegex    = regexp.MustCompile(`^\s*(?:>\s?)+`)
+	tableRuleRegex     = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	thematicBreakRegex = regexp.MustCompile(`^ {0,3}([-*_])(\s*[-*_]){2,}\s*$`)
+)
+
+type markdownSection struct {
+	headings  []string
+	level     int
+	startLine int
+	endLine   int
+	body      []string
+}
+
+// extractMarkdown splits a markdown document into one section per heading. Each section records its heading path
+// and holds only its own body, with markdown formatting stripped; nested headings produce their own sections.
+func extractMarkdown(content []byte, _ map[string]string) ([]*pb.Section, error) {
+	lines := strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+
+	current := &markdownSection{startLine: 1}
+	sections := []*markdownSection{current}
+	var stack []string
+
+	startSection := func(level int, heading string, line int) {
+		if level > len(stack)+1 {
+			level = len(stack) + 1
+		}
+		stack = append(stack[:level-1], heading)
+
+		current = &markdownSection{
+			headings:  append([]string(nil), stack...),
+			level:     level,
+			startLine: line,
+		}
+		sections = append(sections, current)
+	}
+
+	fence := ""
+	for i, line := range lines {
+		lineNumber := i + 1
+
+		if m := fenceRegex.FindStringSubmatch(line); m != nil {
+			marker := m[1][:3]
+			if fence == "" {
+				fence = marker
+			} else if fence == marker {
+				fence = ""
+			}
+			current.body = append(current.body, line)
+			current.endLine = lineNumber
+			continue
+		}
+
+		if fence != "" {
+			current.body = append(current.body, line)
+			current.endLine = lineNumber
+			continue
+		}
+
+		if m := atxHeadingRegex.FindStringSubmatch(line); m != nil {
+			startSection(len(m[1]), stripMarkdown(m[2]), lineNumber)
+			current.endLine = lineNumber
+			continue
+		}
+
+		// a setext underline turns the previous paragraph line into a heading
+		if m := setextHeadingRegex.FindStringSubmatch(line); m != nil && len(current.body) > 0 && strings.TrimSpace(current.body[len(current.body)-1]) != "" && !thematicBreakRegex.MatchString(current.body[len(current.body)-1]) {
+			heading := current.body[len(current.body)-1]
+			current.body = current.body[:len(current.body)-1]
+			current.endLine = lineNumber - 2
+
+			level := 1
+			if m[1][0] == '-' {
+				level = 2
+			}
+			startSection(level, stripMarkdown(heading), lineNumber-1)
+			current.endLine = lineNumber
+			continue
+		}
+
+		current.body = append(current.body, line)
+		current.endLine = lineNumber
+	}
+
+	var result []*pb.Section
+	for _, s := range sections {
+		body := stripMarkdownBlock(s.body)
+		if len(s.headings) == 0 && body == "" {
+			continue
+		}
+
+		path := strings.Join(s.headings, headingPathSeparator)
+		kind := "heading"
+		if len(s.headings) == 0 {
+			path = preamblePath
+			kind = "preamble"
+		}
+
+		text := body
+		if len(s.headings) > 0 {
+			text = strings.TrimSpace(s.headings[len(s.headings)-1] + "\n" + body)
+		}
+
+		result = append(result, &pb.Section{
+			Path:      path,
+			Kind:      kind,
+			Text:      text,
+			StartLine: int32(s.startLine),
+			EndLine:   int32(s.endLine),
+			Attributes: map[string]string{
+				"level": fmt.Sprint(s.level),
+			},
+		})
+	}
+
+	return result, nil
+}
+
+// stripMarkdownBlock removes markdown syntax from a block of lines, keeping only their text.
+// The contents of fenced code blocks are kept as they are.
+func stripMarkdownBlock(lines []string) string {
+	var out []string
+	blank := false
+	emit := func(line string) {
+		if line == "" {
+			blank = len(out) > 0
+			return
+		}
+		if blank {
+			out = append(out, "")
+			blank = false
+		}
+		out = append(out, line)
+	}
+
+	fence := ""
+	for _, line := range lines {
+		if m := fenceRegex.FindStringSubmatch(line); m != nil {
+			marker := m[1][:3]
+			if fence == "" {
+				fence = marker
+			} else if fence == marker {
+				fence = ""
+			}
+			continue
+		}
+
+		if fence != "" {
+			emit(strings.TrimRight(line, " \t"))
+			continue
+		}
+
+		isTableRule := tableRuleRegex.MatchString(line) && strings.Contains(line, "|")
+		if linkDefRegex.MatchString(line) || isTableRule || thematicBreakRegex.MatchString(line) {
+			continue
+		}
+
+		if m := atxHeadingRegex.FindStringSubmatch(line); m != nil {
+			line = m[2]
+		}
+
+		// list items and blockquotes can contain one another
+		line = blockquoteRegex.ReplaceAllString(line, "")
+		line = listMarkerRegex.ReplaceAllString(line, "")
+		line = blockquoteRegex.ReplaceAllString(line, "")
+		line = strings.TrimSpace(stripMarkdown(line))
+		if strings.HasPrefix(line, "|") || strings.HasSuffix(line, "|") {
+			cells := strings.Split(strings.Trim(line, "|"), "|")
+			for i, c := range cells {
+				cells[i] = strings.TrimSpace(c)
+			}
+			line = strings.Join(cells, " ")
+		}
+
+		emit(line)
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// stripMarkdown removes inline markdown formatting, such as emphasis, links and code spans, from a line.
+func stripMarkdown(s string) string {
+	s = imageRegex.ReplaceAllString(s, "$1")
+	s = linkRegex.ReplaceAllString(s, "$1")
+	s = refLinkRegex.ReplaceAllString(s, "$1")
+	s = htmlTagRegex.ReplaceAllString(s, "")
+	s = strings.ReplaceAll(s, "`", "")
+
+	// emphasis can be nested, eg. ***bold italic***
+	for i := 0; i < 3; i++ {
+		stripped := stripEmphasis(s)
+		if stripped == s {
+			break
+		}
+		s = stripped
+	}
+
+	return strings.TrimSpace(s)
+}
+
+// stripEmphasis removes one level of emphasis markers. Underscores only count as emphasis
+// at word boundaries, so identifiers such as snake_case_names are left untouched.
+func stripEmphasis(s string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range emphasisRegex.FindAllStringSubmatchIndex(s, -1) {
+		opening, inner, closing := s[m[2]:m[3]], s[m[4]:m[5]], s[m[6]:m[7]]
+		if opening != closing {
+			continue
+		}
+		if opening[0] == '_' && (isWordByte(s, m[0]-1) || isWordByte(s, m[1])) {
+			continue
+		}
+		b.WriteString(s[last:m[0]])
+		b.WriteString(inner)
+		last = m[1]
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+func isWordByte(s string, i int) bool {
+	if i < 0 || i >= len(s) {
+		return false
+	}
+	c := s[i]
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
@@ -0,0 +1,113 @@
+package extractor
+
+import (
+	"reflect"
+	"testing"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+const testMarkdown = `Some **intro** text.
+
+# README
+
+See the [docs](https://example.com) for *more*.
+
+## Intended Usage
+
+` + "```" + `
+# not a heading
+semantifly add data README.md
+` + "```" + `
+
+### RAG
+
+- Use ` + "`semantifly query`" + ` with snake_case_flags
+- > quoted __text__
+
+Setext Heading
+--------------
+
+| Column | Other |
+|--------|-------|
+| a      | b     |
+`
+
+func TestExtractMarkdown(t *testing.T) {
+	sections, err := Extract(&pb.ContentMetadata{DataType: pb.DataType_MARKDOWN}, []byte(testMarkdown))
+	if err != nil {
+		t.Fatalf("Extract() returned unexpected error: %v", err)
+	}
+
+	expected := []*pb.Section{
+		{
+			Path:       "(preamble)",
+			Kind:       "preamble",
+			Text:       "Some intro text.",
+			StartLine:  1,
+			EndLine:    2,
+			Attributes: map[string]string{"level": "0"},
+		},
+		{
+			Path:       "README",
+			Kind:       "heading",
+			Text:       "README\nSee the docs for more.",
+			StartLine:  3,
+			EndLine:    6,
+			Attributes: map[string]string{"level": "1"},
+		},
+		{
+			Path:       "README > Intended Usage",
+			Kind:       "heading",
+			Text:       "Intended Usage\n# not a heading\nsemantifly add data README.md",
+			StartLine:  7,
+			EndLine:    13,
+			Attributes: map[string]string{"level": "2"},
+		},
+		{
+			Path:       "README > Intended Usage > RAG",
+			Kind:       "heading",
+			Text:       "RAG\nUse semantifly query with snake_case_flags\nquoted text",
+			StartLine:  14,
+			EndLine:    18,
+			Attributes: map[string]string{"level": "3"},
+		},
+		{
+			Path:       "README > Setext Heading",
+			Kind:       "heading",
+			Text:       "Setext Heading\nColumn Other\na b",
+			StartLine:  19,
+			EndLine:    25,
+			Attributes: map[string]string{"level": "2"},
+		},
+	}
+
+	if len(sections) != len(expected) {
+		t.Fatalf("Expected %d sections, got %d: %v", len(expected), len(sections), sections)
+	}
+
+	for i := range expected {
+		got, want := sections[i], expected[i]
+		if got.Path != want.Path || got.Kind != want.Kind || got.Text != want.Text ||
+			got.StartLine != want.StartLine || got.EndLine != want.EndLine || !reflect.DeepEqual(got.Attributes, want.Attributes) {
+			t.Errorf("Section %d:\ngot  %v\nwant %v", i, got, want)
+		}
+	}
+}
+
+func TestStripMarkdown(t *testing.T) {
+	tests := map[string]string{
+		"**bold** and _italic_":         "bold and italic",
+		"***both***":                    "both",
+		"keep snake_case_names":         "keep snake_case_names",
+		"![logo](logo.png) [link][ref]": "logo link",
+		"`code` <b>html</b> ~~struck~~": "code html struck",
+		"2 * 3 * 4":                     "2 * 3 * 4",
+	}
+
+	for input, want := range tests {
+		if got := stripMarkdown(input); got != want {
+			t.Errorf("stripMarkdown(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
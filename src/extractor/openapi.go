@@ -0,0 +1,421 @@
+package extractor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+// Maximum depth to which nested and referenced schemas are rendered, which also guards against recursive schemas.
+const maxSchemaDepth = 6
+
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+type openAPIExtractor struct {
+	doc map[string]interface{}
+}
+
+// extractOpenAPI parses an OpenAPI 3 or Swagger 2 specification, in JSON or YAML, and produces a section for each operation.
+// Local $refs to components or definitions are resolved and rendered inline.
+func extractOpenAPI(content []byte, _ map[string]string) ([]*pb.Section, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse specification: %w", err)
+	}
+
+	var raw interface{}
+	if err := root.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to decode specification: %w", err)
+	}
+
+	doc, ok := normalizeYAML(raw).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("specification is not an object")
+	}
+
+	if doc["openapi"] == nil && doc["swagger"] == nil {
+		return nil, fmt.Errorf("missing 'openapi' or 'swagger' version field")
+	}
+
+	e := &openAPIExtractor{doc: doc}
+
+	paths := asMap(doc["paths"])
+	pathNames := sortedKeys(paths)
+	pathNodes := mappingEntries(mappingEntries(documentNode(&root))["paths"].value)
+
+	var sections []*pb.Section
+	for _, p := range pathNames {
+		pathItem := e.resolve(asMap(paths[p]), nil)
+		methodNodes := mappingEntries(pathNodes[p].value)
+
+		for _, method := range openAPIMethods {
+			op, ok := pathItem[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			section := e.operationSection(p, method, pathItem, op)
+			if entry, ok := methodNodes[method]; ok {
+				section.StartLine = int32(entry.key.Line)
+				section.EndLine = int32(lastLine(entry.value))
+			}
+			sections = append(sections, section)
+		}
+	}
+
+	return sections, nil
+}
+
+func (e *openAPIExtractor) operationSection(p, method string, pathItem, op map[string]interface{}) *pb.Section {
+	name := strings.ToUpper(method) + " " + p
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n", name)
+	if id, ok := op["operationId"].(string); ok {
+		fmt.Fprintf(&b, "operationId: %s\n", id)
+	}
+	for _, key := range []string{"summary", "description"} {
+		if text, ok := op[key].(string); ok && strings.TrimSpace(text) != "" {
+			fmt.Fprintf(&b, "%s\n", strings.TrimSpace(text))
+		}
+	}
+
+	var tags []string
+	for _, tag := range asSlice(op["tags"]) {
+		tags = append(tags, fmt.Sprint(tag))
+	}
+	if len(tags) > 0 {
+		fmt.Fprintf(&b, "tags: %s\n", strings.Join(tags, ", "))
+	}
+
+	// path level parameters apply to every operation unless overridden
+	params := make(map[string]map[string]interface{})
+	var paramOrder []string
+	for _, list := range []interface{}{pathItem["parameters"], op["parameters"]} {
+		for _, p := range asSlice(list) {
+			param := e.resolve(asMap(p), nil)
+			key := fmt.Sprintf("%v:%v", param["in"], param["name"])
+			if _, seen := params[key]; !seen {
+				paramOrder = append(paramOrder, key)
+			}
+			params[key] = param
+		}
+	}
+
+	var bodyParam map[string]interface{}
+	wroteHeader := false
+	for _, key := range paramOrder {
+		param := params[key]
+		if param["in"] == "body" {
+			// Swagger 2 describes the request body as a parameter
+			bodyParam = param
+			continue
+		}
+
+		if !wroteHeader {
+			b.WriteString("parameters:\n")
+			wroteHeader = true
+		}
+
+		fmt.Fprintf(&b, "  %v (%v)", param["name"], param["in"])
+		if schema := asMap(param["schema"]); schema != nil {
+			fmt.Fprintf(&b, ": %s", e.schemaType(schema))
+		} else if t, ok := param["type"]; ok {
+			fmt.Fprintf(&b, ": %v", t)
+		}
+		if required, _ := param["required"].(bool); required {
+			b.WriteString(", required")
+		}
+		if desc, ok := param["description"].(string); ok {
+			fmt.Fprintf(&b, " - %s", oneLine(desc))
+		}
+		b.WriteString("\n")
+	}
+
+	if bodyParam != nil {
+		b.WriteString("request body:\n")
+		e.writeSchema(&b, asMap(bodyParam["schema"]), "  ", 0, nil)
+	}
+
+	if body := e.resolve(asMap(op["requestBody"]), nil); body != nil {
+		content := asMap(body["content"])
+		for _, mime := range sortedKeys(content) {
+			fmt.Fprintf(&b, "request body (%s):\n", mime)
+			e.writeSchema(&b, asMap(asMap(content[mime])["schema"]), "  ", 0, nil)
+		}
+	}
+
+	responses := asMap(op["responses"])
+	if len(responses) > 0 {
+		b.WriteString("responses:\n")
+	}
+	for _, code := range sortedKeys(responses) {
+		resp := e.resolve(asMap(responses[code]), nil)
+		fmt.Fprintf(&b, "  %s", code)
+		if desc, ok := resp["description"].(string); ok && desc != "" {
+			fmt.Fprintf(&b, " - %s", oneLine(desc))
+		}
+		b.WriteString("\n")
+
+		if schema := asMap(resp["schema"]); schema != nil {
+			e.writeSchema(&b, schema, "    ", 0, nil)
+		}
+		content := asMap(resp["content"])
+		for _, mime := range sortedKeys(content) {
+			fmt.Fprintf(&b, "    %s:\n", mime)
+			e.writeSchema(&b, asMap(asMap(content[mime])["schema"]), "      ", 0, nil)
+		}
+	}
+
+	attributes := map[string]string{
+		"method": strings.ToUpper(method),
+		"path":   p,
+	}
+	if id, ok := op["operationId"].(string); ok {
+		attributes["operation_id"] = id
+	}
+	if len(tags) > 0 {
+		attributes["tags"] = strings.Join(tags, ",")
+	}
+
+	return &pb.Section{
+		Path:       name,
+		Kind:       "operation",
+		Text:       strings.TrimRight(b.String(), "\n"),
+		Attributes: attributes,
+	}
+}
+
+// writeSchema renders a schema, and the schemas it references, as an indented outline.
+func (e *openAPIExtractor) writeSchema(b *strings.Builder, schema map[string]interface{}, indent string, depth int, seen map[string]bool) {
+	if schema == nil {
+		return
+	}
+
+	ref, _ := schema["$ref"].(string)
+	if ref != "" {
+		if seen[ref] {
+			fmt.Fprintf(b, "%s%s (recursive)\n", indent, refName(ref))
+			return
+		}
+		seen = withSeen(seen, ref)
+	}
+	schema = e.resolve(schema, nil)
+
+	fmt.Fprintf(b, "%s%s", indent, e.schemaType(schema))
+	if ref != "" {
+		fmt.Fprintf(b, " %s", refName(ref))
+	}
+	if desc, ok := schema["description"].(string); ok && desc != "" {
+		fmt.Fprintf(b, " - %s", oneLine(desc))
+	}
+	b.WriteString("\n")
+
+	if depth >= maxSchemaDepth {
+		return
+	}
+
+	for _, combinator := range []string{"allOf", "oneOf", "anyOf"} {
+		for _, sub := range asSlice(schema[combinator]) {
+			fmt.Fprintf(b, "%s  %s:\n", indent, combinator)
+			e.writeSchema(b, asMap(sub), indent+"    ", depth+1, seen)
+		}
+	}
+
+	required := make(map[string]bool)
+	for _, r := range asSlice(schema["required"]) {
+		required[fmt.Sprint(r)] = true
+	}
+
+	properties := asMap(schema["properties"])
+	for _, prop := range sortedKeys(properties) {
+		propSchema := asMap(properties[prop])
+		label := prop
+		if required[prop] {
+			label += " (required)"
+		}
+
+		if e.isScalar(propSchema) {
+			fmt.Fprintf(b, "%s  %s: ", indent, label)
+			e.writeSchema(b, propSchema, "", depth+1, seen)
+			continue
+		}
+
+		fmt.Fprintf(b, "%s  %s:\n", indent, label)
+		e.writeSchema(b, propSchema, indent+"    ", depth+1, seen)
+	}
+
+	if items := asMap(schema["items"]); items != nil && !e.isScalar(items) {
+		fmt.Fprintf(b, "%s  items:\n", indent)
+		e.writeSchema(b, items, indent+"    ", depth+1, seen)
+	}
+}
+
+func (e *openAPIExtractor) schemaType(schema map[string]interface{}) string {
+	schema = e.resolve(schema, nil)
+
+	t := fmt.Sprint(schema["type"])
+	if schema["type"] == nil {
+		t = "object"
+		if schema["properties"] == nil && schema["allOf"] == nil && schema["oneOf"] == nil && schema["anyOf"] == nil {
+			t = "any"
+		}
+	}
+
+	if t == "array" {
+		items := asMap(schema["items"])
+		if ref, ok := items["$ref"].(string); ok {
+			return "array of " + refName(ref)
+		}
+		return "array of " + e.schemaType(items)
+	}
+
+	if format, ok := schema["format"].(string); ok {
+		t += " (" + format + ")"
+	}
+
+	if enum := asSlice(schema["enum"]); len(enum) > 0 {
+		values := make([]string, 0, len(enum))
+		for _, v := range enum {
+			values = append(values, fmt.Sprint(v))
+		}
+		t += " enum [" + strings.Join(values, ", ") + "]"
+	}
+
+	return t
+}
+
+// resolve follows local $refs, eg. "#/components/schemas/User", until it reaches an object without one.
+func (e *openAPIExtractor) resolve(obj map[string]interface{}, seen map[string]bool) map[string]interface{} {
+	ref, ok := obj["$ref"].(string)
+	if !ok || !strings.HasPrefix(ref, "#/") || seen[ref] {
+		return obj
+	}
+
+	var target interface{} = e.doc
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+		target = asMap(target)[part]
+	}
+
+	resolved := asMap(target)
+	if resolved == nil {
+		return obj
+	}
+
+	return e.resolve(resolved, withSeen(seen, ref))
+}
+
+// isScalar reports whether a schema can be rendered on a single line, ie. it has no properties to list.
+func (e *openAPIExtractor) isScalar(schema map[string]interface{}) bool {
+	schema = e.resolve(schema, nil)
+	if schema["properties"] != nil || schema["allOf"] != nil || schema["oneOf"] != nil || schema["anyOf"] != nil {
+		return false
+	}
+
+	switch schema["type"] {
+	case "object", nil:
+		return false
+	case "array":
+		return e.isScalar(asMap(schema["items"]))
+	}
+
+	return true
+}
+
+func withSeen(seen map[string]bool, ref string) map[string]bool {
+	next := make(map[string]bool, len(seen)+1)
+	for k := range seen {
+		next[k] = true
+	}
+	next[ref] = true
+	return next
+}
+
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// normalizeYAML converts maps with non-string keys, such as unquoted response codes, to map[string]interface{}.
+func normalizeYAML(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			v[k] = normalizeYAML(val)
+		}
+		return v
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			m[fmt.Sprint(k)] = normalizeYAML(val)
+		}
+		return m
+	case []interface{}:
+		for i, val := range v {
+			v[i] = normalizeYAML(val)
+		}
+		return v
+	}
+	return v
+}
+
+func asMap(v interface{}) map[string]interface{} {
+	m, _ := v.(map[string]interface{})
+	return m
+}
+
+func asSlice(v interface{}) []interface{} {
+	s, _ := v.([]interface{})
+	return s
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func documentNode(root *yaml.Node) *yaml.Node {
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		return root.Content[0]
+	}
+	return root
+}
+
+type yamlEntry struct {
+	key   *yaml.Node
+	value *yaml.Node
+}
+
+// mappingEntries returns the key and value nodes of a yaml mapping node by key.
+func mappingEntries(node *yaml.Node) map[string]yamlEntry {
+	entries := make(map[string]yamlEntry)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return entries
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		entries[node.Content[i].Value] = yamlEntry{key: node.Content[i], value: node.Content[i+1]}
+	}
+	return entries
+}
+
+func lastLine(node *yaml.Node) int {
+	line := node.Line
+	for _, child := range node.Content {
+		if l := lastLine(child); l > line {
+			line = l
+		}
+	}
+	return line
+}
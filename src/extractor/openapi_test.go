@@ -0,0 +1,182 @@
+package extractor
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+const testOpenAPI = `openapi: 3.0.0
+info:
+  title: Users API
+  version: "1.0"
+paths:
+  /users/{id}:
+    parameters:
+      - $ref: '#/components/parameters/UserId'
+    get:
+      operationId: getUser
+      summary: Fetch a user
+      tags: [users]
+      responses:
+        200:
+          description: The user
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+        404:
+          description: Not found
+    delete:
+      operationId: deleteUser
+      responses:
+        204:
+          description: Deleted
+  /users:
+    post:
+      operationId: createUser
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/User'
+      responses:
+        201:
+          description: Created
+components:
+  parameters:
+    UserId:
+      name: id
+      in: path
+      required: true
+      schema:
+        type: string
+  schemas:
+    User:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        manager:
+          $ref: '#/components/schemas/User'
+        roles:
+          type: array
+          items:
+            $ref: '#/components/schemas/Role'
+    Role:
+      type: object
+      properties:
+        title:
+          type: string
+          enum: [admin, viewer]
+`
+
+const testSwagger = `{
+  "swagger": "2.0",
+  "paths": {
+    "/pets": {
+      "post": {
+        "operationId": "addPet",
+        "parameters": [
+          {"name": "pet", "in": "body", "schema": {"$ref": "#/definitions/Pet"}},
+          {"name": "dry_run", "in": "query", "type": "boolean", "description": "Validate only"}
+        ],
+        "responses": {
+          "200": {"description": "OK", "schema": {"type": "array", "items": {"$ref": "#/definitions/Pet"}}}
+        }
+      }
+    }
+  },
+  "definitions": {
+    "Pet": {"type": "object", "properties": {"id": {"type": "integer", "format": "int64"}}}
+  }
+}`
+
+func TestExtractOpenAPI(t *testing.T) {
+	sections, err := Extract(&pb.ContentMetadata{DataType: pb.DataType_OPENAPI}, []byte(testOpenAPI))
+	if err != nil {
+		t.Fatalf("Extract() returned unexpected error: %v", err)
+	}
+
+	got := make(map[string]*pb.Section)
+	var paths []string
+	for _, s := range sections {
+		got[s.Path] = s
+		paths = append(paths, s.Path)
+	}
+
+	expectedPaths := []string{"POST /users", "GET /users/{id}", "DELETE /users/{id}"}
+	if !reflect.DeepEqual(paths, expectedPaths) {
+		t.Fatalf("Expected section paths %v, got %v", expectedPaths, paths)
+	}
+
+	getUser := got["GET /users/{id}"]
+	expectedAttributes := map[string]string{
+		"method":       "GET",
+		"path":         "/users/{id}",
+		"operation_id": "getUser",
+		"tags":         "users",
+	}
+	if !reflect.DeepEqual(getUser.Attributes, expectedAttributes) {
+		t.Errorf("Expected attributes %v, got %v", expectedAttributes, getUser.Attributes)
+	}
+	if getUser.StartLine != 9 || getUser.EndLine != 21 {
+		t.Errorf("Expected lines 9-21, got %d-%d", getUser.StartLine, getUser.EndLine)
+	}
+	for _, want := range []string{
+		"Fetch a user",
+		"id (path): string, required",
+		"200 - The user",
+		"object User",
+		"name (required): string",
+		"manager:\n",
+		"User (recursive)",
+		"roles:\n",
+		"array of Role",
+		"title: string enum [admin, viewer]",
+		"404 - Not found",
+	} {
+		if !strings.Contains(getUser.Text, want) {
+			t.Errorf("Expected operation text to contain %q, got:\n%s", want, getUser.Text)
+		}
+	}
+
+	if !strings.Contains(got["POST /users"].Text, "request body (application/json):\n  object User") {
+		t.Errorf("Expected request body schema to be resolved, got:\n%s", got["POST /users"].Text)
+	}
+}
+
+func TestExtractOpenAPI_Swagger(t *testing.T) {
+	sections, err := Extract(&pb.ContentMetadata{DataType: pb.DataType_OPENAPI}, []byte(testSwagger))
+	if err != nil {
+		t.Fatalf("Extract() returned unexpected error: %v", err)
+	}
+
+	if len(sections) != 1 {
+		t.Fatalf("Expected 1 section, got %d", len(sections))
+	}
+
+	text := sections[0].Text
+	for _, want := range []string{
+		"POST /pets",
+		"operationId: addPet",
+		"request body:\n  object Pet",
+		"id: integer (int64)",
+		"dry_run (query): boolean - Validate only",
+		"200 - OK\n    array of Pet",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected operation text to contain %q, got:\n%s", want, text)
+		}
+	}
+}
+
+func TestExtractOpenAPI_NotASpec(t *testing.T) {
+	_, err := Extract(&pb.ContentMetadata{DataType: pb.DataType_OPENAPI}, []byte("title: not an api\n"))
+	if err == nil {
+		t.Error("Expected an error for a document without a version field, but got nil")
+	}
+}
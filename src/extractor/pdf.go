@@ -0,0 +1,113 @@
+package extractor
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+// extractPDF produces a section with the text of each page of a PDF document. Text is reassembled into
+// lines from the positions of the glyphs on the page, since PDFs do not store words or lines as such.
+func extractPDF(content []byte, _ map[string]string) (sections []*pb.Section, err error) {
+	// the pdf package reports malformed content by panicking
+	defer func() {
+		if r := recover(); r != nil {
+			sections = nil
+			err = fmt.Errorf("failed to read PDF: %v", r)
+		}
+	}()
+
+	r, err := pdf.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open PDF: %w", err)
+	}
+
+	for i := 1; i <= r.NumPage(); i++ {
+		page := r.Page(i)
+		if page.V.IsNull() {
+			continue
+		}
+
+		text := pageText(page.Content().Text)
+		if text == "" {
+			continue
+		}
+
+		sections = append(sections, &pb.Section{
+			Path: fmt.Sprintf("page %d", i),
+			Kind: "page",
+			Text: text,
+			Attributes: map[string]string{
+				"page": fmt.Sprint(i),
+			},
+		})
+	}
+
+	return sections, nil
+}
+
+// pageText groups the glyphs of a page into lines, top to bottom, and joins them into text,
+// inserting spaces where the gap between consecutive glyphs is wide enough to separate words.
+func pageText(glyphs []pdf.Text) string {
+	if len(glyphs) == 0 {
+		return ""
+	}
+
+	type line struct {
+		y      float64
+		glyphs []pdf.Text
+	}
+
+	var lines []*line
+	for _, g := range glyphs {
+		tolerance := math.Max(g.FontSize*0.3, 1)
+
+		var current *line
+		for _, l := range lines {
+			if math.Abs(l.y-g.Y) <= tolerance {
+				current = l
+				break
+			}
+		}
+		if current == nil {
+			current = &line{y: g.Y}
+			lines = append(lines, current)
+		}
+		current.glyphs = append(current.glyphs, g)
+	}
+
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].y > lines[j].y
+	})
+
+	var out []string
+	for _, l := range lines {
+		sort.SliceStable(l.glyphs, func(i, j int) bool {
+			return l.glyphs[i].X < l.glyphs[j].X
+		})
+
+		var b strings.Builder
+		for i, g := range l.glyphs {
+			if i > 0 {
+				prev := l.glyphs[i-1]
+				gap := g.X - (prev.X + prev.W)
+				if gap > math.Max(prev.FontSize, g.FontSize)*0.15 && !strings.HasSuffix(prev.S, " ") && !strings.HasPrefix(g.S, " ") {
+					b.WriteString(" ")
+				}
+			}
+			b.WriteString(g.S)
+		}
+
+		if text := strings.Join(strings.Fields(b.String()), " "); text != "" {
+			out = append(out, text)
+		}
+	}
+
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,101 @@
+package extractor
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+// buildTestPDF writes a minimal PDF with one page per entry of pages, each page holding the given lines of text.
+func buildTestPDF(pages [][]string) []byte {
+	var objects []string
+
+	pageCount := len(pages)
+	fontObj := 3 + 2*pageCount
+	kids := make([]string, pageCount)
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 3+2*i)
+	}
+
+	objects = append(objects, "<< /Type /Catalog /Pages 2 0 R >>")
+	objects = append(objects, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), pageCount))
+
+	for i, lines := range pages {
+		var stream strings.Builder
+		stream.WriteString("BT\n/F1 12 Tf\n72 720 Td\n")
+		for j, line := range lines {
+			if j > 0 {
+				stream.WriteString("0 -16 Td\n")
+			}
+			fmt.Fprintf(&stream, "(%s) Tj\n", line)
+		}
+		stream.WriteString("ET")
+
+		objects = append(objects, fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 %d 0 R >> >> /Contents %d 0 R >>", fontObj, 4+2*i))
+		objects = append(objects, fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", stream.Len(), stream.String()))
+	}
+
+	widths := make([]string, 95)
+	for i := range widths {
+		widths[i] = "600"
+	}
+	objects = append(objects, fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /FirstChar 32 /LastChar 126 /Widths [%s] >>", strings.Join(widths, " ")))
+
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return b.Bytes()
+}
+
+func TestExtractPDF(t *testing.T) {
+	content := buildTestPDF([][]string{
+		{"Architecture Overview", "The gateway calls the users service."},
+		{},
+		{"Appendix"},
+	})
+
+	sections, err := Extract(&pb.ContentMetadata{DataType: pb.DataType_PDF}, content)
+	if err != nil {
+		t.Fatalf("Extract() returned unexpected error: %v", err)
+	}
+
+	if len(sections) != 2 {
+		t.Fatalf("Expected 2 sections, got %d: %v", len(sections), sections)
+	}
+
+	first := sections[0]
+	if first.Path != "page 1" || first.Kind != "page" || first.Attributes["page"] != "1" {
+		t.Errorf("Unexpected first page section: %v", first)
+	}
+	expectedText := "Architecture Overview\nThe gateway calls the users service."
+	if first.Text != expectedText {
+		t.Errorf("Expected page text:\n%s\ngot:\n%s", expectedText, first.Text)
+	}
+
+	// the empty second page is skipped, but page numbers still refer to the document
+	if sections[1].Path != "page 3" || sections[1].Attributes["page"] != "3" || sections[1].Text != "Appendix" {
+		t.Errorf("Unexpected last page section: %v", sections[1])
+	}
+}
+
+func TestExtractPDF_Invalid(t *testing.T) {
+	_, err := Extract(&pb.ContentMetadata{DataType: pb.DataType_PDF}, []byte("not a pdf"))
+	if err == nil {
+		t.Error("Expected an error for invalid PDF content, but got nil")
+	}
+}
@@ -0,0 +1,247 @@
+package extractor
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/bufbuild/protocompile/parser"
+	"github.com/bufbuild/protocompile/reporter"
+	"github.com/bufbuild/protocompile/sourceinfo"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+// Field numbers of FileDescriptorProto and its children, as used in SourceCodeInfo location paths.
+const (
+	fileMessageTag   = 4
+	fileEnumTag      = 5
+	fileServiceTag   = 6
+	messageFieldTag  = 2
+	messageNestedTag = 3
+	messageEnumTag   = 4
+	enumValueTag     = 2
+	serviceMethodTag = 2
+)
+
+const protoSourceName = "content.proto"
+
+type protoLocations map[string]*descriptorpb.SourceCodeInfo_Location
+
+func (l protoLocations) get(path []int32) *descriptorpb.SourceCodeInfo_Location {
+	return l[fmt.Sprint(path)]
+}
+
+type protoExtractor struct {
+	pkg       string
+	locations protoLocations
+	sections  []*pb.Section
+}
+
+// extractProto parses a .proto file and produces a section for each message, enum, service and rpc it defines.
+// The file is only parsed, not linked, so imports do not need to be available and type names are kept as written.
+func extractProto(content []byte, _ map[string]string) ([]*pb.Section, error) {
+	handler := reporter.NewHandler(nil)
+	file, err := parser.Parse(protoSourceName, bytes.NewReader(content), handler)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse proto file: %w", err)
+	}
+
+	result, err := parser.ResultFromAST(file, false, handler)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build descriptor: %w", err)
+	}
+
+	fd := result.FileDescriptorProto()
+
+	e := &protoExtractor{
+		pkg:       fd.GetPackage(),
+		locations: make(protoLocations),
+	}
+	for _, loc := range sourceinfo.GenerateSourceInfo(file, nil).GetLocation() {
+		e.locations[fmt.Sprint(loc.Path)] = loc
+	}
+
+	for i, m := range fd.MessageType {
+		e.addMessage(m, e.pkg, []int32{fileMessageTag, int32(i)})
+	}
+	for i, en := range fd.EnumType {
+		e.addEnum(en, e.pkg, []int32{fileEnumTag, int32(i)})
+	}
+	for i, s := range fd.Service {
+		e.addService(s, []int32{fileServiceTag, int32(i)})
+	}
+
+	return e.sections, nil
+}
+
+func (e *protoExtractor) addMessage(m *descriptorpb.DescriptorProto, scope string, path []int32) {
+	name := qualify(scope, m.GetName())
+
+	mapEntries := make(map[string]*descriptorpb.DescriptorProto)
+	for _, nested := range m.NestedType {
+		if nested.GetOptions().GetMapEntry() {
+			mapEntries[qualify(name, nested.GetName())] = nested
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "message %s\n", name)
+	writeComments(&b, e.locations.get(path), "")
+
+	fieldNames := make([]string, 0, len(m.Field))
+	for i, f := range m.Field {
+		fieldNames = append(fieldNames, f.GetName())
+		fmt.Fprintf(&b, "  %s %s = %d;", e.fieldType(f, name, mapEntries), f.GetName(), f.GetNumber())
+		loc := e.locations.get(appendPath(path, messageFieldTag, i))
+		if comment := strings.TrimSpace(loc.GetTrailingComments() + " " + loc.GetLeadingComments()); comment != "" {
+			fmt.Fprintf(&b, " // %s", strings.Join(strings.Fields(comment), " "))
+		}
+		b.WriteString("\n")
+	}
+
+	e.addSection(name, "message", b.String(), path, map[string]string{
+		"fields": strings.Join(fieldNames, ","),
+	})
+
+	for i, nested := range m.NestedType {
+		if nested.GetOptions().GetMapEntry() {
+			continue
+		}
+		e.addMessage(nested, name, appendPath(path, messageNestedTag, i))
+	}
+	for i, en := range m.EnumType {
+		e.addEnum(en, name, appendPath(path, messageEnumTag, i))
+	}
+}
+
+func (e *protoExtractor) addEnum(en *descriptorpb.EnumDescriptorProto, scope string, path []int32) {
+	name := qualify(scope, en.GetName())
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "enum %s\n", name)
+	writeComments(&b, e.locations.get(path), "")
+
+	valueNames := make([]string, 0, len(en.Value))
+	for i, v := range en.Value {
+		valueNames = append(valueNames, v.GetName())
+		fmt.Fprintf(&b, "  %s = %d;\n", v.GetName(), v.GetNumber())
+		writeComments(&b, e.locations.get(appendPath(path, enumValueTag, i)), "    ")
+	}
+
+	e.addSection(name, "enum", b.String(), path, map[string]string{
+		"values": strings.Join(valueNames, ","),
+	})
+}
+
+func (e *protoExtractor) addService(s *descriptorpb.ServiceDescriptorProto, path []int32) {
+	name := qualify(e.pkg, s.GetName())
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "service %s\n", name)
+	writeComments(&b, e.locations.get(path), "")
+
+	methodNames := make([]string, 0, len(s.Method))
+	for _, m := range s.Method {
+		methodNames = append(methodNames, m.GetName())
+		fmt.Fprintf(&b, "  %s\n", rpcSignature(m))
+	}
+
+	e.addSection(name, "service", b.String(), path, map[string]string{
+		"rpcs": strings.Join(methodNames, ","),
+	})
+
+	for i, m := range s.Method {
+		methodPath := appendPath(path, serviceMethodTag, i)
+
+		var mb strings.Builder
+		fmt.Fprintf(&mb, "%s\n", rpcSignature(m))
+		fmt.Fprintf(&mb, "service %s\n", name)
+		writeComments(&mb, e.locations.get(methodPath), "")
+
+		e.addSection(qualify(name, m.GetName()), "rpc", mb.String(), methodPath, map[string]string{
+			"service":     name,
+			"input_type":  strings.TrimPrefix(m.GetInputType(), "."),
+			"output_type": strings.TrimPrefix(m.GetOutputType(), "."),
+		})
+	}
+}
+
+func (e *protoExtractor) addSection(name, kind, text string, path []int32, attributes map[string]string) {
+	section := &pb.Section{
+		Path:       name,
+		Kind:       kind,
+		Text:       strings.TrimRight(text, "\n"),
+		Attributes: attributes,
+	}
+
+	// Spans are zero-based [start line, start column, (end line,) end column].
+	if loc := e.locations.get(path); loc != nil && len(loc.Span) >= 3 {
+		section.StartLine = loc.Span[0] + 1
+		section.EndLine = loc.Span[0] + 1
+		if len(loc.Span) == 4 {
+			section.EndLine = loc.Span[2] + 1
+		}
+	}
+
+	e.sections = append(e.sections, section)
+}
+
+func (e *protoExtractor) fieldType(f *descriptorpb.FieldDescriptorProto, scope string, mapEntries map[string]*descriptorpb.DescriptorProto) string {
+	typeName := strings.TrimPrefix(f.GetTypeName(), ".")
+
+	if entry, ok := mapEntries[qualify(scope, typeName)]; ok && len(entry.Field) == 2 {
+		return fmt.Sprintf("map<%s, %s>", e.fieldType(entry.Field[0], scope, nil), e.fieldType(entry.Field[1], scope, nil))
+	}
+
+	if typeName == "" {
+		typeName = strings.ToLower(strings.TrimPrefix(f.GetType().String(), "TYPE_"))
+	}
+
+	switch {
+	case f.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED:
+		return "repeated " + typeName
+	case f.GetProto3Optional():
+		return "optional " + typeName
+	}
+
+	return typeName
+}
+
+func rpcSignature(m *descriptorpb.MethodDescriptorProto) string {
+	input := strings.TrimPrefix(m.GetInputType(), ".")
+	if m.GetClientStreaming() {
+		input = "stream " + input
+	}
+
+	output := strings.TrimPrefix(m.GetOutputType(), ".")
+	if m.GetServerStreaming() {
+		output = "stream " + output
+	}
+
+	return fmt.Sprintf("rpc %s(%s) returns (%s)", m.GetName(), input, output)
+}
+
+func writeComments(b *strings.Builder, loc *descriptorpb.SourceCodeInfo_Location, indent string) {
+	for _, comment := range []string{loc.GetLeadingComments(), loc.GetTrailingComments()} {
+		for _, line := range strings.Split(strings.TrimSpace(comment), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				fmt.Fprintf(b, "%s%s\n", indent, line)
+			}
+		}
+	}
+}
+
+func qualify(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+func appendPath(path []int32, tag int32, index int) []int32 {
+	p := make([]int32, len(path), len(path)+2)
+	copy(p, path)
+	return append(p, tag, int32(index))
+}
@@ -0,0 +1,127 @@
+package extractor
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+const testProto = `syntax = "proto3";
+package example;
+
+import "other.proto";
+
+// A user of the service.
+message User {
+  string name = 1; // Display name
+  repeated string emails = 2;
+  map<string, int32> scores = 3;
+  other.Address address = 4;
+
+  enum Role {
+    VIEWER = 0;
+    ADMIN = 1;
+  }
+}
+
+service Users {
+  // Fetches a single user.
+  rpc GetUser(GetUserRequest) returns (User) {}
+  rpc WatchUsers(GetUserRequest) returns (stream User) {}
+}
+`
+
+func TestExtractProto(t *testing.T) {
+	sections, err := Extract(&pb.ContentMetadata{DataType: pb.DataType_PROTO}, []byte(testProto))
+	if err != nil {
+		t.Fatalf("Extract() returned unexpected error: %v", err)
+	}
+
+	got := make(map[string]*pb.Section)
+	var paths []string
+	for _, s := range sections {
+		got[s.Path] = s
+		paths = append(paths, s.Path)
+	}
+
+	expectedPaths := []string{
+		"example.User",
+		"example.User.Role",
+		"example.Users",
+		"example.Users.GetUser",
+		"example.Users.WatchUsers",
+	}
+	if !reflect.DeepEqual(paths, expectedPaths) {
+		t.Fatalf("Expected section paths %v, got %v", expectedPaths, paths)
+	}
+
+	user := got["example.User"]
+	if user.Kind != "message" {
+		t.Errorf("Expected kind 'message', got '%s'", user.Kind)
+	}
+	if user.StartLine != 7 || user.EndLine != 17 {
+		t.Errorf("Expected lines 7-17, got %d-%d", user.StartLine, user.EndLine)
+	}
+	for _, want := range []string{
+		"A user of the service.",
+		"string name = 1; // Display name",
+		"repeated string emails = 2;",
+		"map<string, int32> scores = 3;",
+		"other.Address address = 4;",
+	} {
+		if !strings.Contains(user.Text, want) {
+			t.Errorf("Expected message text to contain %q, got:\n%s", want, user.Text)
+		}
+	}
+	if user.Attributes["fields"] != "name,emails,scores,address" {
+		t.Errorf("Unexpected fields attribute: %s", user.Attributes["fields"])
+	}
+
+	if role := got["example.User.Role"]; role.Kind != "enum" || role.Attributes["values"] != "VIEWER,ADMIN" {
+		t.Errorf("Unexpected enum section: %v", role)
+	}
+
+	getUser := got["example.Users.GetUser"]
+	if getUser.Kind != "rpc" {
+		t.Errorf("Expected kind 'rpc', got '%s'", getUser.Kind)
+	}
+	if getUser.Attributes["output_type"] != "User" || getUser.Attributes["input_type"] != "GetUserRequest" {
+		t.Errorf("Unexpected rpc attributes: %v", getUser.Attributes)
+	}
+	if !strings.Contains(getUser.Text, "Fetches a single user.") {
+		t.Errorf("Expected rpc text to contain its comment, got:\n%s", getUser.Text)
+	}
+
+	if watch := got["example.Users.WatchUsers"]; !strings.Contains(watch.Text, "returns (stream User)") {
+		t.Errorf("Expected streaming rpc signature, got:\n%s", watch.Text)
+	}
+}
+
+func TestExtractProto_Invalid(t *testing.T) {
+	_, err := Extract(&pb.ContentMetadata{DataType: pb.DataType_PROTO}, []byte("message {"))
+	if err == nil {
+		t.Error("Expected an error for an invalid proto file, but got nil")
+	}
+}
+
+func TestText(t *testing.T) {
+	content := []byte("plain text content")
+
+	text, err := Text(&pb.ContentMetadata{DataType: pb.DataType_TEXT}, content)
+	if err != nil {
+		t.Fatalf("Text() returned unexpected error: %v", err)
+	}
+	if text != string(content) {
+		t.Errorf("Expected TEXT content to be returned as is, got %q", text)
+	}
+
+	text, err = Text(&pb.ContentMetadata{DataType: pb.DataType_PROTO}, []byte(testProto))
+	if err != nil {
+		t.Fatalf("Text() returned unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(text, "message example.User\n") {
+		t.Errorf("Expected PROTO text to start with the first message, got:\n%s", text)
+	}
+}
@@ -0,0 +1,304 @@
+package extractor
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+var (
+	bareKeyRegex   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+	pathIndexRegex = regexp.MustCompile(`\[\d+\]`)
+)
+
+// maxYAMLNodes bounds the number of nodes a YAML document may expand to, as aliases expand to the whole node they
+// refer to, and nested aliases can make a small document expand to billions of them.
+const maxYAMLNodes = 100000
+
+// flatDocument accumulates the key-path/value pairs of a structured document.
+type flatDocument struct {
+	lines     []string
+	terms     []string
+	seen      map[string]bool
+	startLine int
+	endLine   int
+	// nodes counts the YAML nodes flattened, aliases expanded
+	nodes int
+}
+
+func newFlatDocument() *flatDocument {
+	return &flatDocument{seen: make(map[string]bool)}
+}
+
+// addTerm records a key path as a searchable term, along with its form without array indices,
+// so that "spec.containers.image" matches "spec.containers[0].image" as well.
+func (d *flatDocument) addTerm(path string) {
+	if path == "" {
+		return
+	}
+	for _, term := range []string{path, pathIndexRegex.ReplaceAllString(path, "")} {
+		if !d.seen[term] {
+			d.seen[term] = true
+			d.terms = append(d.terms, term)
+		}
+	}
+}
+
+// addValue records a leaf value. Values spanning several lines are continued on indented lines.
+func (d *flatDocument) addValue(path, value string) {
+	d.addTerm(path)
+
+	if strings.Contains(value, "\n") {
+		value = "|\n  " + strings.ReplaceAll(strings.TrimRight(value, "\n"), "\n", "\n  ")
+	}
+	if path == "" {
+		d.lines = append(d.lines, value)
+		return
+	}
+	d.lines = append(d.lines, path+": "+value)
+}
+
+func (d *flatDocument) markLine(line int) {
+	if line <= 0 {
+		return
+	}
+	if d.startLine == 0 || line < d.startLine {
+		d.startLine = line
+	}
+	if line > d.endLine {
+		d.endLine = line
+	}
+}
+
+func (d *flatDocument) section(index int) *pb.Section {
+	return &pb.Section{
+		Path:      fmt.Sprintf("document[%d]", index),
+		Kind:      "document",
+		Text:      strings.Join(d.lines, "\n"),
+		StartLine: int32(d.startLine),
+		EndLine:   int32(d.endLine),
+		Attributes: map[string]string{
+			"document": fmt.Sprint(index),
+		},
+		Terms: d.terms,
+	}
+}
+
+// keyPath appends a mapping key to a path, quoting keys that are not plain identifiers,
+// eg. metadata.labels["app.kubernetes.io/name"].
+func keyPath(parent, key string) string {
+	if !bareKeyRegex.MatchString(key) {
+		return parent + "[" + strconv.Quote(key) + "]"
+	}
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+func indexPath(parent string, i int) string {
+	return fmt.Sprintf("%s[%d]", parent, i)
+}
+
+// extractJSON flattens a JSON document into one section of key-path/value pairs,
+// eg. "spec.template.spec.containers[0].image: nginx".
+func extractJSON(content []byte, _ map[string]string) ([]*pb.Section, error) {
+	var v any
+	if err := json.Unmarshal(content, &v); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	// JSON is a subset of YAML, and parsing it as such keeps the key order and line numbers
+	return extractYAML(content, nil)
+}
+
+// extractYAML flattens each document of a YAML stream into its own section of key-path/value pairs. Streams of
+// several documents are usually added as one entry per document, see fetcher.SplitYAMLDocuments.
+// Anchors and aliases are expanded, and merge keys ("<<") add their entries to the enclosing mapping.
+func extractYAML(content []byte, _ map[string]string) ([]*pb.Section, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+
+	var sections []*pb.Section
+	for i := 0; ; i++ {
+		var node yaml.Node
+		err := decoder.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse document %d: %w", i, err)
+		}
+
+		if len(node.Content) == 0 {
+			continue
+		}
+
+		doc := newFlatDocument()
+		if err := flattenYAML(doc, "", node.Content[0], 0); err != nil {
+			return nil, fmt.Errorf("failed to flatten document %d: %w", i, err)
+		}
+		if len(doc.lines) == 0 {
+			continue
+		}
+		sections = append(sections, doc.section(i))
+	}
+
+	return sections, nil
+}
+
+func flattenYAML(doc *flatDocument, path string, node *yaml.Node, depth int) error {
+	// guards against aliases that refer to an enclosing node
+	if depth > 64 {
+		return nil
+	}
+	doc.nodes++
+	if doc.nodes > maxYAMLNodes {
+		return fmt.Errorf("document expands to more than %d nodes", maxYAMLNodes)
+	}
+	doc.markLine(node.Line)
+
+	switch node.Kind {
+	case yaml.AliasNode:
+		return flattenYAML(doc, path, node.Alias, depth+1)
+
+	case yaml.MappingNode:
+		if len(node.Content) == 0 {
+			doc.addValue(path, "{}")
+			return nil
+		}
+		doc.addTerm(path)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Tag == "!!merge" {
+				if err := flattenYAML(doc, path, value, depth+1); err != nil {
+					return err
+				}
+				continue
+			}
+			doc.markLine(key.Line)
+			if err := flattenYAML(doc, keyPath(path, key.Value), value, depth+1); err != nil {
+				return err
+			}
+		}
+
+	case yaml.SequenceNode:
+		if len(node.Content) == 0 {
+			doc.addValue(path, "[]")
+			return nil
+		}
+		doc.addTerm(path)
+		for i, item := range node.Content {
+			if err := flattenYAML(doc, indexPath(path, i), item, depth+1); err != nil {
+				return err
+			}
+		}
+
+	case yaml.ScalarNode:
+		value := node.Value
+		if node.Tag == "!!null" {
+			value = "null"
+		}
+		doc.addValue(path, value)
+		doc.markLine(node.Line + strings.Count(strings.TrimRight(value, "\n"), "\n"))
+	}
+
+	return nil
+}
+
+// extractTOML flattens a TOML document into one section of key-path/value pairs, in the order keys are defined.
+func extractTOML(content []byte, _ map[string]string) ([]*pb.Section, error) {
+	var v map[string]any
+	md, err := toml.Decode(string(content), &v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse TOML: %w", err)
+	}
+
+	// maps do not keep the order keys were defined in, but the metadata does
+	order := make(map[string]int)
+	for i, key := range md.Keys() {
+		if _, ok := order[key.String()]; !ok {
+			order[key.String()] = i
+		}
+	}
+
+	doc := newFlatDocument()
+	flattenTOML(doc, "", nil, v, order)
+	if len(doc.lines) == 0 {
+		return nil, nil
+	}
+
+	return []*pb.Section{doc.section(0)}, nil
+}
+
+// flattenTOML walks a decoded TOML value. keys holds the TOML key of the value without array
+// indices, which is how the decoder's metadata refers to the keys of arrays of tables.
+func flattenTOML(doc *flatDocument, path string, keys []string, value any, order map[string]int) {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			doc.addValue(path, "{}")
+			return
+		}
+		doc.addTerm(path)
+
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		position := func(name string) int {
+			if i, ok := order[toml.Key(append(keys[:len(keys):len(keys)], name)).String()]; ok {
+				return i
+			}
+			return len(order)
+		}
+		sort.SliceStable(names, func(i, j int) bool {
+			pi, pj := position(names[i]), position(names[j])
+			if pi != pj {
+				return pi < pj
+			}
+			return names[i] < names[j]
+		})
+
+		for _, name := range names {
+			flattenTOML(doc, keyPath(path, name), append(keys[:len(keys):len(keys)], name), v[name], order)
+		}
+
+	case []map[string]any:
+		if len(v) == 0 {
+			doc.addValue(path, "[]")
+			return
+		}
+		doc.addTerm(path)
+		for i, item := range v {
+			flattenTOML(doc, indexPath(path, i), keys, item, order)
+		}
+
+	case []any:
+		if len(v) == 0 {
+			doc.addValue(path, "[]")
+			return
+		}
+		doc.addTerm(path)
+		for i, item := range v {
+			flattenTOML(doc, indexPath(path, i), keys, item, order)
+		}
+
+	case time.Time:
+		doc.addValue(path, v.Format(time.RFC3339Nano))
+
+	default:
+		doc.addValue(path, fmt.Sprint(v))
+	}
+}
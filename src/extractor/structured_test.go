@@ -0,0 +1,185 @@
+package extractor
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+func TestExtractYAML_MultiDocument(t *testing.T) {
+	content := `apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  labels:
+    app.kubernetes.io/name: web
+---
+apiVersion: apps/v1
+kind: Deployment
+spec:
+  template:
+    spec:
+      containers:
+        - name: web
+          image: nginx:1.25
+          args: []
+          command: |
+            nginx
+            -g daemon off;
+`
+	sections, err := Extract(&pb.ContentMetadata{DataType: pb.DataType_YAML}, []byte(content))
+	if err != nil {
+		t.Fatalf("Extract() returned unexpected error: %v", err)
+	}
+
+	if len(sections) != 2 {
+		t.Fatalf("Expected 2 sections, got %d", len(sections))
+	}
+
+	service := sections[0]
+	if service.Path != "document[0]" || service.Kind != "document" {
+		t.Errorf("Unexpected section path and kind: %s %s", service.Path, service.Kind)
+	}
+	if service.StartLine != 1 || service.EndLine != 6 {
+		t.Errorf("Expected lines 1-6, got %d-%d", service.StartLine, service.EndLine)
+	}
+
+	expectedText := `apiVersion: v1
+kind: Service
+metadata.name: web
+metadata.labels["app.kubernetes.io/name"]: web`
+	if service.Text != expectedText {
+		t.Errorf("Unexpected text:\n%s\nwant:\n%s", service.Text, expectedText)
+	}
+
+	deployment := sections[1]
+	if deployment.Path != "document[1]" || deployment.StartLine != 8 || deployment.EndLine != 18 {
+		t.Errorf("Unexpected deployment section: %s lines %d-%d", deployment.Path, deployment.StartLine, deployment.EndLine)
+	}
+	for _, want := range []string{
+		"spec.template.spec.containers[0].image: nginx:1.25",
+		"spec.template.spec.containers[0].args: []",
+		"spec.template.spec.containers[0].command: |\n  nginx\n  -g daemon off;",
+	} {
+		if !strings.Contains(deployment.Text, want) {
+			t.Errorf("Expected text to contain %q, got:\n%s", want, deployment.Text)
+		}
+	}
+	for _, want := range []string{
+		"spec.template.spec.containers[0].image",
+		"spec.template.spec.containers.image",
+		"spec.template",
+	} {
+		if !slices.Contains(deployment.Terms, want) {
+			t.Errorf("Expected terms to contain %q, got %v", want, deployment.Terms)
+		}
+	}
+}
+
+func TestExtractYAML_Aliases(t *testing.T) {
+	content := `defaults: &defaults
+  retries: 3
+production:
+  <<: *defaults
+  host: example.com
+`
+	sections, err := Extract(&pb.ContentMetadata{DataType: pb.DataType_YAML}, []byte(content))
+	if err != nil {
+		t.Fatalf("Extract() returned unexpected error: %v", err)
+	}
+
+	expected := "defaults.retries: 3\nproduction.retries: 3\nproduction.host: example.com"
+	if len(sections) != 1 || sections[0].Text != expected {
+		t.Errorf("Unexpected sections: %v", sections)
+	}
+}
+
+func TestExtractYAML_AliasBomb(t *testing.T) {
+	// each level refers to the previous one ten times, expanding to 10^9 values
+	var sb strings.Builder
+	sb.WriteString("a: &a [x, x, x, x, x, x, x, x, x, x]\n")
+	for i, name := range []string{"b", "c", "d", "e", "f", "g", "h", "i"} {
+		previous := string(rune('a' + i))
+		fmt.Fprintf(&sb, "%s: &%s [*%s, *%s, *%s, *%s, *%s, *%s, *%s, *%s, *%s, *%s]\n", name, name,
+			previous, previous, previous, previous, previous, previous, previous, previous, previous, previous)
+	}
+
+	_, err := Extract(&pb.ContentMetadata{DataType: pb.DataType_YAML}, []byte(sb.String()))
+	if err == nil || !strings.Contains(err.Error(), "more than") {
+		t.Errorf("Expected an error for a document expanding to too many nodes, got %v", err)
+	}
+}
+
+func TestExtractJSON(t *testing.T) {
+	content := `{
+  "name": "semantifly",
+  "scripts": {"build": "go build ./..."},
+  "tags": ["search", "rag"],
+  "private": true,
+  "license": null
+}`
+	sections, err := Extract(&pb.ContentMetadata{DataType: pb.DataType_JSON}, []byte(content))
+	if err != nil {
+		t.Fatalf("Extract() returned unexpected error: %v", err)
+	}
+
+	if len(sections) != 1 {
+		t.Fatalf("Expected 1 section, got %d", len(sections))
+	}
+
+	expected := `name: semantifly
+scripts.build: go build ./...
+tags[0]: search
+tags[1]: rag
+private: true
+license: null`
+	if sections[0].Text != expected {
+		t.Errorf("Unexpected text:\n%s\nwant:\n%s", sections[0].Text, expected)
+	}
+	if sections[0].StartLine != 1 || sections[0].EndLine != 6 {
+		t.Errorf("Expected lines 1-6, got %d-%d", sections[0].StartLine, sections[0].EndLine)
+	}
+
+	if _, err := Extract(&pb.ContentMetadata{DataType: pb.DataType_JSON}, []byte("name: not json")); err == nil {
+		t.Error("Expected an error for invalid JSON, but got nil")
+	}
+}
+
+func TestExtractTOML(t *testing.T) {
+	content := `title = "config"
+
+[server]
+port = 8080
+host = "localhost"
+
+[[server.routes]]
+path = "/api"
+
+[[server.routes]]
+path = "/health"
+`
+	sections, err := Extract(&pb.ContentMetadata{DataType: pb.DataType_TOML}, []byte(content))
+	if err != nil {
+		t.Fatalf("Extract() returned unexpected error: %v", err)
+	}
+
+	if len(sections) != 1 {
+		t.Fatalf("Expected 1 section, got %d", len(sections))
+	}
+
+	// keys keep the order they are defined in
+	expected := []string{
+		"title: config",
+		"server.port: 8080",
+		"server.host: localhost",
+		"server.routes[0].path: /api",
+		"server.routes[1].path: /health",
+	}
+	if got := strings.Split(sections[0].Text, "\n"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Unexpected lines:\n%v\nwant:\n%v", got, expected)
+	}
+}
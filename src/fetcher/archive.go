@@ -0,0 +1,183 @@
+package fetcher
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+// ArchiveSeparator separates the path of an archive from the path of a member within it,
+// eg. "/sdk/bundle.zip!/docs/README.md".
+const ArchiveSeparator = "!/"
+
+var archiveExtensions = []string{".zip", ".tar", ".tar.gz", ".tgz"}
+
+// IsArchive reports whether uri names a supported archive, based on its extension.
+func IsArchive(uri string) bool {
+	lower := strings.ToLower(uri)
+	for _, ext := range archiveExtensions {
+		if strings.HasSuffix(lower, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// ArchiveMemberURI returns the URI of a member within an archive.
+func ArchiveMemberURI(archivePath, member string) string {
+	return archivePath + ArchiveSeparator + member
+}
+
+// SplitArchiveURI splits a member URI into the path of the archive and the path of the member within it.
+func SplitArchiveURI(uri string) (string, string, error) {
+	i := strings.Index(uri, ArchiveSeparator)
+	if i < 0 {
+		return "", "", fmt.Errorf("URI %s does not name an archive member", uri)
+	}
+	return uri[:i], uri[i+len(ArchiveSeparator):], nil
+}
+
+// ListArchiveMembers returns the paths of the regular files within a local archive, in archive order.
+func ListArchiveMembers(archivePath string) ([]string, error) {
+	var members []string
+	err := walkArchive(archivePath, func(name string, _ func() (io.ReadCloser, error)) (bool, error) {
+		members = append(members, name)
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// fetchFromArchive reads a single member from a local archive, addressed as "archive.zip!/path/in/archive".
+func fetchFromArchive(uri string) ([]byte, error) {
+	archivePath, member, err := SplitArchiveURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	var content []byte
+	found := false
+	err = walkArchive(archivePath, func(name string, open func() (io.ReadCloser, error)) (bool, error) {
+		if name != member {
+			return true, nil
+		}
+
+		found = true
+		r, err := open()
+		if err != nil {
+			return false, fmt.Errorf("failed to open member %s: %w", member, err)
+		}
+		defer r.Close()
+
+		content, err = io.ReadAll(r)
+		if err != nil {
+			return false, fmt.Errorf("failed to read member %s: %w", member, err)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, fmt.Errorf("member %s not found in archive %s", member, archivePath)
+	}
+
+	return content, nil
+}
+
+// walkArchive calls visit for each regular file in the archive until it returns false or an error.
+func walkArchive(archivePath string, visit func(name string, open func() (io.ReadCloser, error)) (bool, error)) error {
+	f, err := os.Stat(archivePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("archive %s does not exist", archivePath)
+		}
+		return fmt.Errorf("failed to stat archive: %w", err)
+	}
+
+	if !f.Mode().IsRegular() {
+		return fmt.Errorf("archive %s is not a regular file", archivePath)
+	}
+
+	if strings.HasSuffix(strings.ToLower(archivePath), ".zip") {
+		return walkZip(archivePath, visit)
+	}
+	return walkTar(archivePath, visit)
+}
+
+func walkZip(archivePath string, visit func(string, func() (io.ReadCloser, error)) (bool, error)) error {
+	r, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to open zip archive: %w", err)
+	}
+	defer r.Close()
+
+	for _, file := range r.File {
+		if !file.Mode().IsRegular() {
+			continue
+		}
+
+		more, err := visit(cleanMemberName(file.Name), file.Open)
+		if err != nil || !more {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func walkTar(archivePath string, visit func(string, func() (io.ReadCloser, error)) (bool, error)) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to open tar archive: %w", err)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	lower := strings.ToLower(archivePath)
+	if strings.HasSuffix(lower, ".gz") || strings.HasSuffix(lower, ".tgz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return fmt.Errorf("failed to decompress archive: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tar archive: %w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		open := func() (io.ReadCloser, error) {
+			return io.NopCloser(tr), nil
+		}
+
+		more, err := visit(cleanMemberName(header.Name), open)
+		if err != nil || !more {
+			return err
+		}
+	}
+}
+
+func cleanMemberName(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
@@ -0,0 +1,135 @@
+package fetcher
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+var testArchiveFiles = []struct {
+	name    string
+	content string
+}{
+	{"README.md", "# SDK"},
+	{"./docs/guide.txt", "guide content"},
+}
+
+func writeTestZip(t *testing.T, archivePath string) {
+	f, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	if _, err := zw.Create("docs/"); err != nil {
+		t.Fatalf("Failed to add directory to archive: %v", err)
+	}
+	for _, file := range testArchiveFiles {
+		w, err := zw.Create(file.name)
+		if err != nil {
+			t.Fatalf("Failed to add %s to archive: %v", file.name, err)
+		}
+		if _, err := w.Write([]byte(file.content)); err != nil {
+			t.Fatalf("Failed to write %s to archive: %v", file.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Failed to close archive: %v", err)
+	}
+}
+
+func writeTestTarGz(t *testing.T, archivePath string) {
+	f, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{Name: "docs/", Typeflag: tar.TypeDir, Mode: 0755}); err != nil {
+		t.Fatalf("Failed to add directory to archive: %v", err)
+	}
+	for _, file := range testArchiveFiles {
+		header := &tar.Header{Name: file.name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(file.content))}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("Failed to add %s to archive: %v", file.name, err)
+		}
+		if _, err := tw.Write([]byte(file.content)); err != nil {
+			t.Fatalf("Failed to write %s to archive: %v", file.name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Failed to close tar writer: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("Failed to close gzip writer: %v", err)
+	}
+}
+
+func TestArchives(t *testing.T) {
+	tempDir := t.TempDir()
+
+	archives := map[string]func(*testing.T, string){
+		"bundle.zip":    writeTestZip,
+		"bundle.tar.gz": writeTestTarGz,
+	}
+
+	for name, write := range archives {
+		t.Run(name, func(t *testing.T) {
+			archivePath := filepath.Join(tempDir, name)
+			write(t, archivePath)
+
+			if !IsArchive(archivePath) {
+				t.Fatalf("Expected %s to be recognised as an archive", archivePath)
+			}
+
+			members, err := ListArchiveMembers(archivePath)
+			if err != nil {
+				t.Fatalf("ListArchiveMembers returned an error: %v", err)
+			}
+
+			expectedMembers := []string{"README.md", "docs/guide.txt"}
+			if !reflect.DeepEqual(members, expectedMembers) {
+				t.Errorf("Expected members %v, got %v", expectedMembers, members)
+			}
+
+			content, err := FetchFromSource(pb.SourceType_ARCHIVE, ArchiveMemberURI(archivePath, "docs/guide.txt"))
+			if err != nil {
+				t.Fatalf("FetchFromSource returned an error: %v", err)
+			}
+			if string(content) != "guide content" {
+				t.Errorf("Expected member content 'guide content', got '%s'", content)
+			}
+
+			if _, err := FetchFromSource(pb.SourceType_ARCHIVE, ArchiveMemberURI(archivePath, "missing.txt")); err == nil {
+				t.Errorf("Expected an error for a missing member, but got nil")
+			}
+		})
+	}
+}
+
+func TestSplitArchiveURI(t *testing.T) {
+	archivePath, member, err := SplitArchiveURI("/sdk/bundle.zip!/docs/README.md")
+	if err != nil {
+		t.Fatalf("SplitArchiveURI returned an error: %v", err)
+	}
+	if archivePath != "/sdk/bundle.zip" || member != "docs/README.md" {
+		t.Errorf("Unexpected split: %s, %s", archivePath, member)
+	}
+
+	if _, _, err := SplitArchiveURI("/sdk/README.md"); err == nil {
+		t.Errorf("Expected an error for a URI without an archive member, but got nil")
+	}
+
+	if IsArchive("/sdk/README.md") {
+		t.Errorf("Expected /sdk/README.md not to be recognised as an archive")
+	}
+}
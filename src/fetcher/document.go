@@ -0,0 +1,95 @@
+package fetcher
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// DocumentSeparator separates the URI of a YAML stream from the index of a document within it,
+// eg. "/deploy/app.yaml#document[1]".
+const DocumentSeparator = "#document["
+
+var documentURIRegex = regexp.MustCompile(`^(.+)#document\[(\d+)\]$`)
+
+// YAMLDocumentURI returns the URI of a document within a YAML stream.
+func YAMLDocumentURI(streamURI string, index int) string {
+	return fmt.Sprintf("%s%s%d]", streamURI, DocumentSeparator, index)
+}
+
+// SplitYAMLDocumentURI splits a document URI into the URI of the YAML stream and the index of the document within
+// it, as returned by SplitYAMLDocuments. ok is false if the URI does not name a document.
+func SplitYAMLDocumentURI(uri string) (streamURI string, index int, ok bool) {
+	match := documentURIRegex.FindStringSubmatch(uri)
+	if match == nil {
+		return "", 0, false
+	}
+	index, err := strconv.Atoi(match[2])
+	if err != nil {
+		return "", 0, false
+	}
+	return match[1], index, true
+}
+
+// SplitYAMLDocuments splits a YAML stream at its "---" and "..." markers into the source of each of its documents,
+// so that they parse on their own. Comments and directives are kept with the document that follows them, and
+// documents without any content are left out.
+func SplitYAMLDocuments(content []byte) [][]byte {
+	var documents [][]byte
+	start := 0
+	// started is whether the current document has a start marker or content
+	started, hasContent := false, false
+
+	end := func(offset int) {
+		if hasContent {
+			documents = append(documents, content[start:offset])
+		}
+		start = offset
+		started, hasContent = false, false
+	}
+
+	offset := 0
+	for _, line := range bytes.SplitAfter(content, []byte("\n")) {
+		trimmed := bytes.TrimRight(line, "\r\n")
+
+		switch {
+		case isMarker(trimmed, "---"):
+			if started {
+				end(offset)
+			}
+			started = true
+			// content may follow the marker, eg. "--- |"
+			if len(bytes.TrimSpace(trimmed[3:])) > 0 {
+				hasContent = true
+			}
+
+		case isMarker(trimmed, "..."):
+			end(offset + len(line))
+			offset += len(line)
+			continue
+
+		case len(bytes.TrimSpace(trimmed)) == 0, bytes.HasPrefix(bytes.TrimSpace(trimmed), []byte("#")):
+
+		case bytes.HasPrefix(trimmed, []byte("%")) && !started:
+			// directives come before the start marker of their document
+
+		default:
+			started, hasContent = true, true
+		}
+
+		offset += len(line)
+	}
+	end(len(content))
+
+	return documents
+}
+
+// isMarker reports whether a line is a document marker, which may be followed by content after a space.
+func isMarker(line []byte, marker string) bool {
+	if !bytes.HasPrefix(line, []byte(marker)) {
+		return false
+	}
+	rest := line[len(marker):]
+	return len(rest) == 0 || rest[0] == ' ' || rest[0] == '\t'
+}
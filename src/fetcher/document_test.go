@@ -0,0 +1,80 @@
+package fetcher
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+const testStream = `# services of the app
+apiVersion: v1
+kind: Service
+---
+# comments only
+...
+%YAML 1.2
+---
+apiVersion: apps/v1
+kind: Deployment
+...
+--- |
+  literal document
+`
+
+func TestSplitYAMLDocuments(t *testing.T) {
+	var documents []string
+	for _, document := range SplitYAMLDocuments([]byte(testStream)) {
+		documents = append(documents, string(document))
+	}
+
+	expected := []string{
+		"# services of the app\napiVersion: v1\nkind: Service\n",
+		"%YAML 1.2\n---\napiVersion: apps/v1\nkind: Deployment\n...\n",
+		"--- |\n  literal document\n",
+	}
+	if !reflect.DeepEqual(documents, expected) {
+		t.Errorf("Expected documents %q, got %q", expected, documents)
+	}
+
+	if documents := SplitYAMLDocuments([]byte("key: value\n")); len(documents) != 1 {
+		t.Errorf("Expected a single document, got %q", documents)
+	}
+}
+
+func TestSplitYAMLDocumentURI(t *testing.T) {
+	uri := YAMLDocumentURI("/deploy/app.yaml", 2)
+	if uri != "/deploy/app.yaml#document[2]" {
+		t.Errorf("Unexpected document URI %s", uri)
+	}
+
+	streamURI, index, ok := SplitYAMLDocumentURI(uri)
+	if !ok || streamURI != "/deploy/app.yaml" || index != 2 {
+		t.Errorf("Expected /deploy/app.yaml and 2, got %s, %d, %v", streamURI, index, ok)
+	}
+
+	if _, _, ok := SplitYAMLDocumentURI("/deploy/app.yaml"); ok {
+		t.Errorf("Expected a stream URI not to name a document")
+	}
+}
+
+func TestFetchYAMLDocument(t *testing.T) {
+	streamPath := filepath.Join(t.TempDir(), "app.yaml")
+	if err := os.WriteFile(streamPath, []byte(testStream), 0644); err != nil {
+		t.Fatalf("Failed to write stream: %v", err)
+	}
+
+	content, err := FetchFromSource(pb.SourceType_LOCAL_FILE, YAMLDocumentURI(streamPath, 1))
+	if err != nil {
+		t.Fatalf("FetchFromSource() returned unexpected error: %v", err)
+	}
+	if string(content) != "%YAML 1.2\n---\napiVersion: apps/v1\nkind: Deployment\n...\n" {
+		t.Errorf("Unexpected document %q", content)
+	}
+
+	if _, err := FetchFromSource(pb.SourceType_LOCAL_FILE, YAMLDocumentURI(streamPath, 3)); err == nil {
+		t.Errorf("Expected an error for a missing document")
+	}
+}
@@ -13,25 +13,50 @@ import (
 )
 
 func FetchFromSource(sourceType pb.SourceType, uri string) ([]byte, error) {
-	var fetch func(string) ([]byte, error)
+	content, _, err := FetchWithContentType(sourceType, uri)
+	return content, err
+}
+
+// FetchWithContentType fetches content like FetchFromSource, along with the MIME type reported by the
+// source. Only webpages report one; for other source types the content type is empty.
+// URIs naming a document of a YAML stream, eg. "app.yaml#document[1]", fetch the stream and return the document.
+func FetchWithContentType(sourceType pb.SourceType, uri string) ([]byte, string, error) {
+	if streamURI, index, ok := SplitYAMLDocumentURI(uri); ok {
+		content, contentType, err := FetchWithContentType(sourceType, streamURI)
+		if err != nil {
+			return nil, "", err
+		}
+
+		documents := SplitYAMLDocuments(content)
+		if index >= len(documents) {
+			return nil, "", fmt.Errorf("failed to fetch from source %s: %s has %d documents", uri, streamURI, len(documents))
+		}
+		return documents[index], contentType, nil
+	}
+
+	var content []byte
+	var contentType string
+	var err error
 
 	switch sourceType {
 	case pb.SourceType_LOCAL_FILE:
-		fetch = fetchFromFile
+		content, err = fetchFromFile(uri)
 
 	case pb.SourceType_WEBPAGE:
-		fetch = fetchFromWebpage
+		content, contentType, err = fetchFromWebpage(uri)
+
+	case pb.SourceType_ARCHIVE:
+		content, err = fetchFromArchive(uri)
 
 	default:
-		return nil, fmt.Errorf("invalid sourceType argument")
+		return nil, "", fmt.Errorf("invalid sourceType argument")
 	}
 
-	content, err := fetch(uri)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch from source %s: %w", uri, err)
+		return nil, "", fmt.Errorf("failed to fetch from source %s: %w", uri, err)
 	}
 
-	return content, nil
+	return content, contentType, nil
 }
 
 func fetchFromFile(uri string) ([]byte, error) {
@@ -65,7 +90,7 @@ func fetchFromFile(uri string) ([]byte, error) {
 	return content, nil
 }
 
-func fetchFromWebpage(uri string) ([]byte, error) {
+func fetchFromWebpage(uri string) ([]byte, string, error) {
 
 	// Using a context with timeout for HTTP request
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -73,23 +98,23 @@ func fetchFromWebpage(uri string) ([]byte, error) {
 
 	req, err := http.NewRequestWithContext(ctx, "GET", uri, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %v", err)
+		return nil, "", fmt.Errorf("failed to create request: %v", err)
 	}
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch web page: %v", err)
+		return nil, "", fmt.Errorf("failed to fetch web page: %v", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("web page returned non-OK status: %s", resp.Status)
+		return nil, "", fmt.Errorf("web page returned non-OK status: %s", resp.Status)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read web page content: %v", err)
+		return nil, "", fmt.Errorf("failed to read web page content: %v", err)
 	}
 
-	return body, nil
+	return body, resp.Header.Get("Content-Type"), nil
 }
@@ -0,0 +1,309 @@
+// Package gateway serves the Semantifly service as a REST API of JSON requests and responses, for clients that do
+// not speak gRPC. Requests are decoded into the messages of the service with protojson, from the body of POST and
+// PATCH requests, and from the path and query parameters of the others. Bodies must be sent as application/json,
+// which pages of other origins cannot do without a CORS preflight.
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+// OpenAPIPath is where the OpenAPI description of the API is served
+const OpenAPIPath = "/v1/openapi.json"
+
+const maxBodySize = 16 << 20
+
+var wildcardRegex = regexp.MustCompile(`\{(\w+)(\.\.\.)?\}`)
+
+// route maps requests of a method and path pattern to an RPC of the service.
+type route struct {
+	method string
+	// pattern of http.ServeMux, whose wildcards set the fields of the request message of the same name
+	pattern string
+	// fields set by wildcards not named like them
+	fields  map[string]string
+	summary string
+	// body is whether the request message is read from the body, rather than from the query
+	body bool
+
+	input      protoreflect.MessageDescriptor
+	output     protoreflect.MessageDescriptor
+	newRequest func() proto.Message
+	call       func(ctx context.Context, req proto.Message) (proto.Message, error)
+}
+
+func newRoute[Req, Resp proto.Message](method string, pattern string, summary string, body bool, call func(context.Context, Req) (Resp, error)) *route {
+	var req Req
+	var resp Resp
+	return &route{
+		method:     method,
+		pattern:    pattern,
+		summary:    summary,
+		body:       body,
+		input:      req.ProtoReflect().Descriptor(),
+		output:     resp.ProtoReflect().Descriptor(),
+		newRequest: func() proto.Message { return req.ProtoReflect().Type().New().Interface() },
+		call: func(ctx context.Context, m proto.Message) (proto.Message, error) {
+			return call(ctx, m.(Req))
+		},
+	}
+}
+
+// withField sets the field of the request set by a wildcard of the pattern.
+func (rt *route) withField(wildcard string, field string) *route {
+	if rt.fields == nil {
+		rt.fields = make(map[string]string)
+	}
+	rt.fields[wildcard] = field
+	return rt
+}
+
+// field returns the name of the field of the request set by a wildcard of the pattern.
+func (rt *route) field(wildcard string) string {
+	if field, ok := rt.fields[wildcard]; ok {
+		return field
+	}
+	return wildcard
+}
+
+// Entry names are paths or URLs, whose slashes are escaped as %2F in request paths, as http.ServeMux cleans the
+// unescaped ones, eg. "/v1/entries/%2Fdocs%2Fguide.md".
+func routes(s pb.SemantiflyServer) []*route {
+	return []*route{
+		newRoute(http.MethodPost, "/v1/entries", "Add data to the index", true, s.Add),
+		newRoute(http.MethodGet, "/v1/entries", "List the entries of the index", false, s.List),
+		newRoute(http.MethodGet, "/v1/entries/{name...}", "Get the content of an entry, or of one of its sections or chunks", false, s.Get),
+		newRoute(http.MethodPatch, "/v1/entries/{name...}", "Update an entry", true, s.Update),
+		newRoute(http.MethodDelete, "/v1/entries/{name...}", "Delete an entry", false, s.Delete).withField("name", "names"),
+		newRoute(http.MethodGet, "/v1/descriptions/{name...}", "Describe an entry", false, s.DescribeData),
+		newRoute(http.MethodPost, "/v1/types", "Add a user defined type backed by a protobuf message", true, s.AddType),
+		newRoute(http.MethodGet, "/v1/types/{name}", "Describe a user defined type", false, s.DescribeType),
+		newRoute(http.MethodPost, "/v1/embed", "Compute vector embeddings of chunks", true, s.Embed),
+		newRoute(http.MethodPost, "/v1/search", "Search for the chunks most related to a query", true, s.SemanticSearch),
+		newRoute(http.MethodPost, "/v1/lexical-search", "Search for the entries containing a term", true, s.LexicalSearch),
+		newRoute(http.MethodPost, "/v1/context", "Assemble the chunks most related to a question into a context", true, s.Context),
+		newRoute(http.MethodPost, "/v1/generate", "Answer a question with a language model given the chunks most related to it", true, s.Generate),
+	}
+}
+
+// Gateway is the http.Handler of the REST API.
+type Gateway struct {
+	mux            *http.ServeMux
+	allowedOrigins map[string]bool
+}
+
+// New returns a gateway calling the given implementation of the service, typically the one registered with the
+// gRPC server. Browsers may call the API from pages of allowedOrigins, eg. "http://localhost:3000", or of any
+// origin if it holds "*".
+func New(s pb.SemantiflyServer, allowedOrigins []string) (*Gateway, error) {
+	g := &Gateway{mux: http.NewServeMux(), allowedOrigins: make(map[string]bool)}
+	for _, origin := range allowedOrigins {
+		g.allowedOrigins[origin] = true
+	}
+
+	rs := routes(s)
+	for _, r := range rs {
+		g.mux.HandleFunc(r.method+" "+r.pattern, r.serve)
+	}
+
+	document, err := openAPI(rs)
+	if err != nil {
+		return nil, err
+	}
+	g.mux.HandleFunc(http.MethodGet+" "+OpenAPIPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(document)
+	})
+
+	return g, nil
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if origin := r.Header.Get("Origin"); origin != "" && (g.allowedOrigins[origin] || g.allowedOrigins["*"]) {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Add("Vary", "Origin")
+
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PATCH, DELETE")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+
+	g.mux.ServeHTTP(w, r)
+}
+
+func (rt *route) serve(w http.ResponseWriter, r *http.Request) {
+	req := rt.newRequest()
+
+	if rt.body {
+		if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+			writeMessage(w, http.StatusUnsupportedMediaType, status.Newf(codes.InvalidArgument, "expected a Content-Type of application/json, got %q", r.Header.Get("Content-Type")).Proto())
+			return
+		}
+
+		data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+		if err != nil {
+			writeError(w, status.Errorf(codes.InvalidArgument, "failed to read the request body: %v", err))
+			return
+		}
+		if len(bytes.TrimSpace(data)) > 0 {
+			if err := protojson.Unmarshal(data, req); err != nil {
+				writeError(w, status.Errorf(codes.InvalidArgument, "failed to parse the request body: %v", err))
+				return
+			}
+		}
+	}
+
+	// path parameters are set on top of the body, and query parameters of requests without one
+	params := url.Values{}
+	if !rt.body {
+		params = r.URL.Query()
+	}
+	for _, match := range wildcardRegex.FindAllStringSubmatch(rt.pattern, -1) {
+		params.Set(rt.field(match[1]), r.PathValue(match[1]))
+	}
+	if err := setParams(req, params); err != nil {
+		writeError(w, status.Error(codes.InvalidArgument, err.Error()))
+		return
+	}
+
+	resp, err := rt.call(r.Context(), req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeMessage(w, http.StatusOK, resp)
+}
+
+// setParams sets the fields of a message named by parameters, by their name in the proto or in JSON. Only fields
+// of scalar, enum, Duration and Timestamp types, or lists of them, can be set.
+func setParams(m proto.Message, params url.Values) error {
+	if len(params) == 0 {
+		return nil
+	}
+
+	fields := m.ProtoReflect().Descriptor().Fields()
+	object := make(map[string]any)
+	for name, values := range params {
+		field := fields.ByName(protoreflect.Name(name))
+		if field == nil {
+			field = fields.ByJSONName(name)
+		}
+		if field == nil || !queryField(field) {
+			return fmt.Errorf("unknown parameter %s", name)
+		}
+
+		var parsed []any
+		for _, value := range values {
+			parsed = append(parsed, paramValue(field, value))
+		}
+		if field.IsList() {
+			object[field.JSONName()] = parsed
+		} else {
+			object[field.JSONName()] = parsed[len(parsed)-1]
+		}
+	}
+
+	data, err := json.Marshal(object)
+	if err != nil {
+		return fmt.Errorf("failed to marshal parameters: %v", err)
+	}
+
+	decoded := m.ProtoReflect().Type().New().Interface()
+	if err := protojson.Unmarshal(data, decoded); err != nil {
+		return fmt.Errorf("invalid parameters: %v", err)
+	}
+	proto.Merge(m, decoded)
+
+	return nil
+}
+
+// queryField reports whether a field can be set from a query parameter.
+func queryField(field protoreflect.FieldDescriptor) bool {
+	if field.IsMap() {
+		return false
+	}
+	if field.Kind() == protoreflect.MessageKind {
+		return wellKnownSchemas[field.Message().FullName()] != nil
+	}
+	return true
+}
+
+// paramValue returns the JSON value of a parameter for protojson, which accepts numbers as strings.
+func paramValue(field protoreflect.FieldDescriptor, value string) any {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	case protoreflect.EnumKind:
+		if upper := strings.ToUpper(value); field.Enum().Values().ByName(protoreflect.Name(upper)) != nil {
+			return upper
+		}
+	}
+	return value
+}
+
+func writeMessage(w http.ResponseWriter, code int, m proto.Message) {
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		code = http.StatusInternalServerError
+		data, _ = protojson.Marshal(status.Newf(codes.Internal, "failed to marshal the response: %v", err).Proto())
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(data)
+}
+
+// writeError writes the status of an error as a google.rpc.Status, eg. {"code": 3, "message": "..."}.
+func writeError(w http.ResponseWriter, err error) {
+	s := status.Convert(err)
+	writeMessage(w, httpStatus(s.Code()), s.Proto())
+}
+
+var httpStatuses = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           499,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+// httpStatus returns the HTTP status code of a gRPC code, as mapped by google.rpc.Code.
+func httpStatus(code codes.Code) int {
+	if s, ok := httpStatuses[code]; ok {
+		return s
+	}
+	return http.StatusInternalServerError
+}
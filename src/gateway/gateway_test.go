@@ -0,0 +1,190 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+// testServer records the requests it receives.
+type testServer struct {
+	pb.UnimplementedSemantiflyServer
+	requests []proto.Message
+}
+
+func (s *testServer) SemanticSearch(ctx context.Context, req *pb.SemanticSearchRequest) (*pb.SemanticSearchResponse, error) {
+	s.requests = append(s.requests, req)
+	return &pb.SemanticSearchResponse{Records: []*pb.SearchRecord{{Name: "guide.md", Score: 0.5}}}, nil
+}
+
+func (s *testServer) Get(ctx context.Context, req *pb.GetRequest) (*pb.GetResponse, error) {
+	s.requests = append(s.requests, req)
+	if req.Name == "missing.md" {
+		return nil, status.Error(codes.NotFound, "entry missing.md not found")
+	}
+	content := "# Guide"
+	return &pb.GetResponse{Content: &content}, nil
+}
+
+func (s *testServer) List(ctx context.Context, req *pb.ListRequest) (*pb.ListResponse, error) {
+	s.requests = append(s.requests, req)
+	return &pb.ListResponse{}, nil
+}
+
+func (s *testServer) Delete(ctx context.Context, req *pb.DeleteRequest) (*pb.DeleteResponse, error) {
+	s.requests = append(s.requests, req)
+	return &pb.DeleteResponse{}, nil
+}
+
+func TestGateway(t *testing.T) {
+	s := &testServer{}
+	gw, err := New(s, []string{"http://localhost:3000"})
+	if err != nil {
+		t.Fatalf("New() returned unexpected error: %v", err)
+	}
+	server := httptest.NewServer(gw)
+	defer server.Close()
+
+	do := func(method string, path string, body string) (int, string) {
+		req, _ := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json; charset=utf-8")
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s %s failed: %v", method, path, err)
+		}
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(data)
+	}
+
+	tests := []struct {
+		method   string
+		path     string
+		body     string
+		code     int
+		response string
+		request  proto.Message
+	}{
+		{
+			http.MethodPost, "/v1/search", `{"query": "users", "max_records": 3, "mode": "HYBRID"}`,
+			http.StatusOK, `"name":"guide.md"`,
+			&pb.SemanticSearchRequest{Query: "users", MaxRecords: 3, Mode: pb.SearchMode_HYBRID},
+		},
+		{
+			http.MethodGet, "/v1/entries/%2Fdocs%2Fguide.md?section=Install&text=true&chunk=2", "",
+			http.StatusOK, `"content":"# Guide"`,
+			&pb.GetRequest{Name: "/docs/guide.md", Section: "Install", Text: true, Chunk: proto.Int32(2)},
+		},
+		{
+			http.MethodGet, "/v1/entries?dataType=MARKDOWN&stale_since=3600s&page_size=5&descending=true", "",
+			http.StatusOK, `{}`,
+			&pb.ListRequest{DataType: "MARKDOWN", StaleSince: durationpb.New(time.Hour), PageSize: 5, Descending: true},
+		},
+		{
+			http.MethodDelete, "/v1/entries/https%3A%2F%2Fexample.com%2Fa?delete_copy=true", "",
+			http.StatusOK, `{}`,
+			&pb.DeleteRequest{Names: []string{"https://example.com/a"}, DeleteCopy: true},
+		},
+		{
+			http.MethodGet, "/v1/entries/missing.md", "",
+			http.StatusNotFound, `"code":5`, nil,
+		},
+		{
+			http.MethodGet, "/v1/entries/guide.md?colour=blue", "",
+			http.StatusBadRequest, `unknown parameter colour`, nil,
+		},
+		{
+			http.MethodPost, "/v1/search", `{"query": `,
+			http.StatusBadRequest, `failed to parse the request body`, nil,
+		},
+		{
+			http.MethodPost, "/v1/generate", `{"question": "why?"}`,
+			http.StatusNotImplemented, `"code":12`, nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.requests = nil
+		code, body := do(tt.method, tt.path, tt.body)
+		if code != tt.code || !strings.Contains(body, tt.response) {
+			t.Errorf("%s %s: expected %d with %s, got %d %s", tt.method, tt.path, tt.code, tt.response, code, body)
+		}
+		if tt.request != nil && (len(s.requests) != 1 || !proto.Equal(s.requests[0], tt.request)) {
+			t.Errorf("%s %s: expected request %v, got %v", tt.method, tt.path, tt.request, s.requests)
+		}
+	}
+
+	req, _ := http.NewRequest(http.MethodOptions, server.URL+"/v1/search", nil)
+	req.Header.Set("Origin", "http://localhost:3000")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Preflight request failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusNoContent || resp.Header.Get("Access-Control-Allow-Origin") != "http://localhost:3000" {
+		t.Errorf("Expected the preflight request to be allowed, got %d %v", resp.StatusCode, resp.Header)
+	}
+
+	// forms of other origins can post text/plain bodies without a preflight
+	s.requests = nil
+	resp, err = http.Post(server.URL+"/v1/search", "text/plain", strings.NewReader(`{"query": "users"}`))
+	if err != nil {
+		t.Fatalf("POST /v1/search failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnsupportedMediaType || len(s.requests) != 0 {
+		t.Errorf("Expected a text/plain body to be rejected, got %d and requests %v", resp.StatusCode, s.requests)
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+	data, err := openAPI(routes(&testServer{}))
+	if err != nil {
+		t.Fatalf("openAPI() returned unexpected error: %v", err)
+	}
+
+	var document struct {
+		Paths      map[string]map[string]map[string]any
+		Components struct {
+			Schemas map[string]map[string]any
+		}
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatalf("Failed to decode the description: %v", err)
+	}
+
+	if document.Paths["/v1/search"]["post"]["operationId"] != "SemanticSearch" || document.Paths["/v1/entries/{name}"]["delete"]["operationId"] != "Delete" {
+		t.Errorf("Unexpected paths %v", document.Paths)
+	}
+
+	record := document.Components.Schemas["SearchRecord"]
+	if record == nil {
+		t.Fatalf("Expected a schema for SearchRecord, got %v", document.Components.Schemas)
+	}
+	properties := record["properties"].(map[string]any)
+	if properties["chunk"].(map[string]any)["$ref"] != "#/components/schemas/Chunk" || properties["score"].(map[string]any)["type"] != "number" {
+		t.Errorf("Unexpected SearchRecord schema %v", record)
+	}
+
+	// references must all resolve
+	for _, ref := range strings.Split(string(data), `"$ref": "#/components/schemas/`)[1:] {
+		name := ref[:strings.Index(ref, `"`)]
+		if document.Components.Schemas[name] == nil {
+			t.Errorf("Reference to missing schema %s", name)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+// wellKnownSchemas are the schemas of the well-known types protojson encodes as strings
+var wellKnownSchemas = map[protoreflect.FullName]map[string]any{
+	"google.protobuf.Timestamp": {"type": "string", "format": "date-time"},
+	"google.protobuf.Duration":  {"type": "string", "description": "Duration in seconds with an \"s\" suffix, eg. \"3600s\""},
+}
+
+// openAPI generates the OpenAPI description of the routes from the descriptors of their messages. Properties are
+// named as protojson encodes them, though requests may also name them as in the proto.
+func openAPI(routes []*route) ([]byte, error) {
+	service := pb.File_semantifly_proto.Services().ByName("Semantifly")
+	schemas := map[string]any{
+		"Status": map[string]any{
+			"type":        "object",
+			"description": "Error of a request, as a google.rpc.Status",
+			"properties": map[string]any{
+				"code":    map[string]any{"type": "integer", "description": "gRPC status code"},
+				"message": map[string]any{"type": "string"},
+				"details": map[string]any{"type": "array", "items": map[string]any{"type": "object"}},
+			},
+		},
+	}
+
+	paths := make(map[string]map[string]any)
+	for _, r := range routes {
+		rpc := rpcName(service, r.input)
+		if rpc == "" {
+			return nil, fmt.Errorf("no RPC of the service takes %s", r.input.FullName())
+		}
+
+		operation := map[string]any{
+			"operationId": rpc,
+			"summary":     r.summary,
+			"responses": map[string]any{
+				"200": map[string]any{
+					"description": "OK",
+					"content":     jsonContent(messageRef(r.output, schemas)),
+				},
+				"default": map[string]any{
+					"description": "Error",
+					"content":     jsonContent(map[string]any{"$ref": "#/components/schemas/Status"}),
+				},
+			},
+		}
+
+		parameters := []any{}
+		pathParams := make(map[string]bool)
+		for _, match := range wildcardRegex.FindAllStringSubmatch(r.pattern, -1) {
+			pathParams[r.field(match[1])] = true
+			field := r.input.Fields().ByName(protoreflect.Name(r.field(match[1])))
+			parameters = append(parameters, map[string]any{
+				"name":     match[1],
+				"in":       "path",
+				"required": true,
+				"schema":   valueSchema(field, schemas),
+			})
+		}
+		if r.body {
+			operation["requestBody"] = map[string]any{
+				"required": true,
+				"content":  jsonContent(messageRef(r.input, schemas)),
+			}
+		} else {
+			fields := r.input.Fields()
+			for i := 0; i < fields.Len(); i++ {
+				field := fields.Get(i)
+				if pathParams[string(field.Name())] || !queryField(field) {
+					continue
+				}
+				parameters = append(parameters, map[string]any{
+					"name":   string(field.Name()),
+					"in":     "query",
+					"schema": fieldSchema(field, schemas),
+				})
+			}
+		}
+		if len(parameters) > 0 {
+			operation["parameters"] = parameters
+		}
+
+		path := wildcardRegex.ReplaceAllString(r.pattern, "{$1}")
+		if paths[path] == nil {
+			paths[path] = make(map[string]any)
+		}
+		paths[path][strings.ToLower(r.method)] = operation
+	}
+
+	paths[OpenAPIPath] = map[string]any{
+		"get": map[string]any{
+			"operationId": "OpenAPI",
+			"summary":     "Get this description of the API",
+			"responses":   map[string]any{"200": map[string]any{"description": "OK", "content": jsonContent(map[string]any{"type": "object"})}},
+		},
+	}
+
+	document := map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "Semantifly",
+			"description": "REST API of the " + string(service.FullName()) + " gRPC service",
+			"version":     "v1",
+		},
+		"paths":      paths,
+		"components": map[string]any{"schemas": schemas},
+	}
+
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the OpenAPI description: %v", err)
+	}
+	return data, nil
+}
+
+// rpcName returns the name of the RPC of a service taking the given input message, or "" if there is none.
+func rpcName(service protoreflect.ServiceDescriptor, input protoreflect.MessageDescriptor) string {
+	methods := service.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		if methods.Get(i).Input().FullName() == input.FullName() {
+			return string(methods.Get(i).Name())
+		}
+	}
+	return ""
+}
+
+func jsonContent(schema map[string]any) map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": schema}}
+}
+
+// messageRef returns a reference to the schema of a message, adding it and the schemas of the messages it refers
+// to to schemas.
+func messageRef(message protoreflect.MessageDescriptor, schemas map[string]any) map[string]any {
+	if schema, ok := wellKnownSchemas[message.FullName()]; ok {
+		return schema
+	}
+
+	name := schemaName(message)
+	ref := map[string]any{"$ref": "#/components/schemas/" + name}
+	if _, ok := schemas[name]; ok {
+		return ref
+	}
+
+	properties := make(map[string]any)
+	schema := map[string]any{"type": "object", "properties": properties}
+	// added before its fields, for messages referring to themselves
+	schemas[name] = schema
+
+	fields := message.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		properties[fields.Get(i).JSONName()] = fieldSchema(fields.Get(i), schemas)
+	}
+
+	return ref
+}
+
+// schemaName returns the name of a message without its package, eg. "SearchRecord".
+func schemaName(message protoreflect.MessageDescriptor) string {
+	return strings.TrimPrefix(string(message.FullName()), string(message.ParentFile().Package())+".")
+}
+
+func fieldSchema(field protoreflect.FieldDescriptor, schemas map[string]any) map[string]any {
+	switch {
+	case field.IsMap():
+		return map[string]any{"type": "object", "additionalProperties": valueSchema(field.MapValue(), schemas)}
+	case field.IsList():
+		return map[string]any{"type": "array", "items": valueSchema(field, schemas)}
+	}
+	return valueSchema(field, schemas)
+}
+
+// valueSchema returns the schema of a single value of a field, as protojson encodes it.
+func valueSchema(field protoreflect.FieldDescriptor, schemas map[string]any) map[string]any {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]any{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]any{"type": "integer", "format": "int64", "minimum": 0}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return map[string]any{"type": "string", "format": "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return map[string]any{"type": "string", "format": "uint64"}
+	case protoreflect.FloatKind:
+		return map[string]any{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		return map[string]any{"type": "number", "format": "double"}
+	case protoreflect.BytesKind:
+		return map[string]any{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind:
+		var names []string
+		values := field.Enum().Values()
+		for i := 0; i < values.Len(); i++ {
+			names = append(names, string(values.Get(i).Name()))
+		}
+		return map[string]any{"type": "string", "enum": names}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return messageRef(field.Message(), schemas)
+	}
+	return map[string]any{"type": "string"}
+}
@@ -0,0 +1,200 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+const DefaultAPIKeyEnv = "OPENAI_API_KEY"
+
+// Message is a message of a chat, from the "system", the "user" or the "assistant".
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// Client is a client of an OpenAI compatible /chat/completions API, as served by OpenAI, llama.cpp, Ollama or vLLM.
+type Client struct {
+	client      *http.Client
+	baseURL     string
+	apiKey      string
+	model       string
+	temperature *float32
+	maxTokens   int
+}
+
+type chatRequest struct {
+	Model       string    `json:"model,omitempty"`
+	Messages    []Message `json:"messages"`
+	Stream      bool      `json:"stream"`
+	Temperature *float32  `json:"temperature,omitempty"`
+	MaxTokens   int       `json:"max_tokens,omitempty"`
+}
+
+// chatChunk is an event of a streamed completion, or a whole completion, which has a message instead of a delta.
+type chatChunk struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Delta   Message `json:"delta"`
+		Message Message `json:"message"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// New creates a client of the API described by config.
+func New(config *pb.GenerationConfig) (*Client, error) {
+	if config.GetBaseUrl() == "" {
+		return nil, fmt.Errorf("no language model is configured, set generation.base_url in the config or pass its address")
+	}
+
+	apiKeyEnv := config.GetApiKeyEnv()
+	if apiKeyEnv == "" {
+		apiKeyEnv = DefaultAPIKeyEnv
+	}
+
+	return &Client{
+		client:      &http.Client{Timeout: 10 * time.Minute},
+		baseURL:     BaseURL(config.GetBaseUrl()),
+		apiKey:      os.Getenv(apiKeyEnv),
+		model:       config.GetModel(),
+		temperature: config.Temperature,
+		maxTokens:   int(config.GetMaxTokens()),
+	}, nil
+}
+
+// BaseURL completes the address of an API into a base URL: "localhost:8080" becomes "http://localhost:8080/v1".
+// URLs with a path are kept as they are.
+func BaseURL(address string) string {
+	address = strings.TrimSuffix(address, "/")
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+	if u, err := url.Parse(address); err == nil && u.Path == "" {
+		address += "/v1"
+	}
+	return address
+}
+
+// Chat sends the messages and streams the reply, calling onDelta with each piece of text as it arrives if it is
+// not nil. It returns the whole reply and the model id reported by the API. Servers that ignore streaming and send
+// the whole completion at once are supported too.
+func (c *Client) Chat(ctx context.Context, messages []Message, onDelta func(string) error) (string, string, error) {
+	body, err := json.Marshal(&chatRequest{Model: c.model, Messages: messages, Stream: true, Temperature: c.temperature, MaxTokens: c.maxTokens})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal chat request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to request a completion: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		parsed := &chatChunk{}
+		if json.Unmarshal(data, parsed) == nil && parsed.Error != nil && parsed.Error.Message != "" {
+			return "", "", fmt.Errorf("chat API returned %s: %s", resp.Status, parsed.Error.Message)
+		}
+		return "", "", fmt.Errorf("chat API returned %s", resp.Status)
+	}
+
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		return c.readCompletion(resp.Body, onDelta)
+	}
+	return c.readStream(resp.Body, onDelta)
+}
+
+// readStream reads server-sent events, each a "data: " line holding a chatChunk, until "data: [DONE]".
+func (c *Client) readStream(r io.Reader, onDelta func(string) error) (string, string, error) {
+	var text strings.Builder
+	model := c.model
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			break
+		}
+
+		chunk := &chatChunk{}
+		if err := json.Unmarshal([]byte(data), chunk); err != nil {
+			return text.String(), model, fmt.Errorf("failed to parse chat stream: %v", err)
+		}
+		if chunk.Error != nil {
+			return text.String(), model, fmt.Errorf("chat API failed: %s", chunk.Error.Message)
+		}
+		if chunk.Model != "" {
+			model = chunk.Model
+		}
+		if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+			continue
+		}
+
+		delta := chunk.Choices[0].Delta.Content
+		text.WriteString(delta)
+		if onDelta != nil {
+			if err := onDelta(delta); err != nil {
+				return text.String(), model, err
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return text.String(), model, fmt.Errorf("failed to read chat stream: %v", err)
+	}
+
+	return text.String(), model, nil
+}
+
+func (c *Client) readCompletion(r io.Reader, onDelta func(string) error) (string, string, error) {
+	completion := &chatChunk{}
+	if err := json.NewDecoder(r).Decode(completion); err != nil {
+		return "", "", fmt.Errorf("failed to parse chat response: %v", err)
+	}
+	if len(completion.Choices) == 0 {
+		return "", "", fmt.Errorf("chat API returned no choices")
+	}
+
+	model := completion.Model
+	if model == "" {
+		model = c.model
+	}
+
+	text := completion.Choices[0].Message.Content
+	if onDelta != nil && text != "" {
+		if err := onDelta(text); err != nil {
+			return text, model, err
+		}
+	}
+
+	return text, model, nil
+}
@@ -0,0 +1,103 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+func TestBaseURL(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"localhost:8080", "http://localhost:8080/v1"},
+		{"http://localhost:8080/", "http://localhost:8080/v1"},
+		{"https://api.openai.com/v1", "https://api.openai.com/v1"},
+		{"http://localhost:11434/api/v1/", "http://localhost:11434/api/v1"},
+	}
+
+	for _, tt := range tests {
+		if got := BaseURL(tt.input); got != tt.expected {
+			t.Errorf("BaseURL(%q) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestChat_Stream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := &chatRequest{}
+		if r.URL.Path != "/v1/chat/completions" || json.NewDecoder(r.Body).Decode(request) != nil || !request.Stream || len(request.Messages) != 2 {
+			http.Error(w, `{"error": {"message": "unexpected request"}}`, http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, delta := range []string{"SELECT", " *", " FROM users"} {
+			fmt.Fprintf(w, "data: {\"model\": \"local\", \"choices\": [{\"delta\": {\"content\": %q}}]}\n\n", delta)
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	client, err := New(&pb.GenerationConfig{BaseUrl: server.URL})
+	if err != nil {
+		t.Fatalf("New() returned unexpected error: %v", err)
+	}
+
+	var deltas []string
+	text, model, err := client.Chat(context.Background(), []Message{{Role: "system", Content: "s"}, {Role: "user", Content: "u"}}, func(delta string) error {
+		deltas = append(deltas, delta)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Chat() returned unexpected error: %v", err)
+	}
+	if text != "SELECT * FROM users" || model != "local" || len(deltas) != 3 {
+		t.Errorf("Unexpected reply %q of %s in %d deltas", text, model, len(deltas))
+	}
+}
+
+func TestChat_Completion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"model": "whole", "choices": [{"message": {"role": "assistant", "content": "hello"}}]}`)
+	}))
+	defer server.Close()
+
+	client, _ := New(&pb.GenerationConfig{BaseUrl: server.URL + "/v1", Model: "requested"})
+	var streamed strings.Builder
+	text, model, err := client.Chat(context.Background(), []Message{{Role: "user", Content: "hi"}}, func(delta string) error {
+		streamed.WriteString(delta)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Chat() returned unexpected error: %v", err)
+	}
+	if text != "hello" || streamed.String() != "hello" || model != "whole" {
+		t.Errorf("Unexpected reply %q of %s, streamed %q", text, model, streamed.String())
+	}
+}
+
+func TestChat_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": {"message": "context length exceeded"}}`, http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client, _ := New(&pb.GenerationConfig{BaseUrl: server.URL})
+	_, _, err := client.Chat(context.Background(), []Message{{Role: "user", Content: "hi"}}, nil)
+	if err == nil || !strings.Contains(err.Error(), "context length exceeded") {
+		t.Errorf("Expected the API's error, got %v", err)
+	}
+
+	if _, err := New(nil); err == nil {
+		t.Error("Expected an error without a base URL")
+	}
+}
@@ -0,0 +1,58 @@
+package mcp
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// ServeHTTP serves the streamable HTTP transport: each message is POSTed, and requests are answered with a single
+// JSON response. The server sends no messages of its own, so it offers no event stream to GET, and keeps no sessions.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !allowedOrigin(r) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	message, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxMessageSize))
+	if err != nil {
+		http.Error(w, "failed to read request: "+err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	reply := s.Handle(r.Context(), message)
+	if reply == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(reply)
+}
+
+// allowedOrigin reports whether a request comes from a page of the server's own host or of a local host, or from
+// a client that is not a browser, to protect local servers from DNS rebinding.
+func allowedOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if u.Host == r.Host {
+		return true
+	}
+
+	host := u.Hostname()
+	return host == "localhost" || net.ParseIP(host).IsLoopback()
+}
@@ -0,0 +1,322 @@
+// Package mcp implements the server side of the Model Context Protocol, the JSON-RPC 2.0 protocol coding assistants
+// use to call tools and read resources, over stdio and streamable HTTP.
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ProtocolVersion is the latest version of the protocol the server supports, answered to clients asking for a
+// version it does not know.
+const ProtocolVersion = "2025-06-18"
+
+var supportedVersions = map[string]bool{
+	"2025-06-18": true,
+	"2025-03-26": true,
+	"2024-11-05": true,
+}
+
+// JSON-RPC error codes
+const (
+	codeParseError       = -32700
+	codeInvalidRequest   = -32600
+	codeMethodNotFound   = -32601
+	codeInvalidParams    = -32602
+	codeInternalError    = -32603
+	codeResourceNotFound = -32002
+)
+
+// maxMessageSize is the size of the largest message read from stdio or an HTTP request
+const maxMessageSize = 16 << 20
+
+// ErrResourceNotFound is returned, possibly wrapped, by ResourceProvider.ReadResource for unknown URIs.
+var ErrResourceNotFound = errors.New("resource not found")
+
+// Tool is a function the client's model may call.
+type Tool struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// JSON schema of the arguments, an object
+	InputSchema json.RawMessage `json:"inputSchema"`
+
+	// Call runs the tool with the JSON object of its arguments and returns text for the model. Errors are reported
+	// to the model as the result of the call rather than as protocol errors, so that it may correct its arguments.
+	Call func(ctx context.Context, arguments json.RawMessage) (string, error) `json:"-"`
+}
+
+// Resource describes data the client may read, eg. to attach it to a conversation.
+type Resource struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+}
+
+// ResourceTemplate describes resources whose URIs are built from parameters, as an RFC 6570 URI template.
+type ResourceTemplate struct {
+	URITemplate string `json:"uriTemplate"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+// ResourceContents is the content of a resource, either text or binary data.
+type ResourceContents struct {
+	URI      string `json:"uri"`
+	MimeType string `json:"mimeType,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Blob     []byte `json:"blob,omitempty"`
+}
+
+// ResourceProvider lists and reads the resources of a server.
+type ResourceProvider interface {
+	ListResources(ctx context.Context) ([]Resource, error)
+	ReadResource(ctx context.Context, uri string) (*ResourceContents, error)
+}
+
+// Server answers the requests of MCP clients with its tools and resources.
+type Server struct {
+	Name    string
+	Version string
+	// Instructions are given to the client's model on how to use the server, if not empty
+	Instructions string
+
+	Tools             []Tool
+	Resources         ResourceProvider
+	ResourceTemplates []ResourceTemplate
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+type content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type callResult struct {
+	Content []content `json:"content"`
+	IsError bool      `json:"isError,omitempty"`
+}
+
+// Handle answers a JSON-RPC message. Notifications and responses sent by the client are not answered, and
+// result in nil.
+func (s *Server) Handle(ctx context.Context, message []byte) []byte {
+	message = bytes.TrimSpace(message)
+	if len(message) > 0 && message[0] == '[' {
+		return encode(&response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{Code: codeInvalidRequest, Message: "batches are not supported"}})
+	}
+
+	req := &request{}
+	if err := json.Unmarshal(message, req); err != nil {
+		return encode(&response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{Code: codeParseError, Message: fmt.Sprintf("failed to parse message: %v", err)}})
+	}
+
+	// responses to requests of the server, which it sends none of, and notifications are not answered
+	if req.Method == "" || len(req.ID) == 0 {
+		return nil
+	}
+
+	resp := &response{JSONRPC: "2.0", ID: req.ID}
+	result, err := s.dispatch(ctx, req)
+	if err != nil {
+		var rpcErr *rpcError
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &rpcError{Code: codeInternalError, Message: err.Error()}
+		}
+		resp.Error = rpcErr
+	} else {
+		resp.Result = result
+	}
+
+	return encode(resp)
+}
+
+func encode(resp *response) []byte {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		data, _ = json.Marshal(&response{JSONRPC: "2.0", ID: resp.ID, Error: &rpcError{Code: codeInternalError, Message: fmt.Sprintf("failed to marshal response: %v", err)}})
+	}
+	return data
+}
+
+func (s *Server) dispatch(ctx context.Context, req *request) (any, error) {
+	switch req.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.initialize(params.ProtocolVersion), nil
+
+	case "ping":
+		return struct{}{}, nil
+
+	case "tools/list":
+		tools := s.Tools
+		if tools == nil {
+			tools = []Tool{}
+		}
+		return map[string]any{"tools": tools}, nil
+
+	case "tools/call":
+		var params struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.callTool(ctx, params.Name, params.Arguments)
+
+	case "resources/list":
+		if s.Resources == nil {
+			return nil, &rpcError{Code: codeMethodNotFound, Message: "the server has no resources"}
+		}
+		resources, err := s.Resources.ListResources(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if resources == nil {
+			resources = []Resource{}
+		}
+		return map[string]any{"resources": resources}, nil
+
+	case "resources/templates/list":
+		templates := s.ResourceTemplates
+		if templates == nil {
+			templates = []ResourceTemplate{}
+		}
+		return map[string]any{"resourceTemplates": templates}, nil
+
+	case "resources/read":
+		if s.Resources == nil {
+			return nil, &rpcError{Code: codeMethodNotFound, Message: "the server has no resources"}
+		}
+		var params struct {
+			URI string `json:"uri"`
+		}
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		contents, err := s.Resources.ReadResource(ctx, params.URI)
+		if errors.Is(err, ErrResourceNotFound) {
+			return nil, &rpcError{Code: codeResourceNotFound, Message: err.Error()}
+		}
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"contents": []*ResourceContents{contents}}, nil
+	}
+
+	return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %s not found", req.Method)}
+}
+
+func decodeParams(params json.RawMessage, v any) error {
+	if len(params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("invalid params: %v", err)}
+	}
+	return nil
+}
+
+func (s *Server) initialize(requested string) map[string]any {
+	version := ProtocolVersion
+	if supportedVersions[requested] {
+		version = requested
+	}
+
+	capabilities := map[string]any{"tools": struct{}{}}
+	if s.Resources != nil {
+		capabilities["resources"] = struct{}{}
+	}
+
+	result := map[string]any{
+		"protocolVersion": version,
+		"capabilities":    capabilities,
+		"serverInfo":      map[string]string{"name": s.Name, "version": s.Version},
+	}
+	if s.Instructions != "" {
+		result["instructions"] = s.Instructions
+	}
+	return result
+}
+
+func (s *Server) callTool(ctx context.Context, name string, arguments json.RawMessage) (*callResult, error) {
+	for _, tool := range s.Tools {
+		if tool.Name != name {
+			continue
+		}
+
+		if len(arguments) == 0 || string(arguments) == "null" {
+			arguments = json.RawMessage("{}")
+		}
+
+		text, err := tool.Call(ctx, arguments)
+		if err != nil {
+			return &callResult{Content: []content{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+		}
+		return &callResult{Content: []content{{Type: "text", Text: text}}}, nil
+	}
+
+	return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown tool %s", name)}
+}
+
+// ServeStdio answers the newline delimited messages read from r on w, until r is closed or ctx is done.
+func (s *Server) ServeStdio(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		if reply := s.Handle(ctx, line); reply != nil {
+			if _, err := w.Write(append(reply, '\n')); err != nil {
+				return fmt.Errorf("failed to write response: %v", err)
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read messages: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type testResources struct{}
+
+func (testResources) ListResources(ctx context.Context) ([]Resource, error) {
+	return []Resource{{URI: "test://a", Name: "a"}}, nil
+}
+
+func (testResources) ReadResource(ctx context.Context, uri string) (*ResourceContents, error) {
+	if uri != "test://a" {
+		return nil, fmt.Errorf("%w: %s", ErrResourceNotFound, uri)
+	}
+	return &ResourceContents{URI: uri, MimeType: "text/plain", Text: "content of a"}, nil
+}
+
+func newTestServer() *Server {
+	return &Server{
+		Name:    "test",
+		Version: "1.0.0",
+		Tools: []Tool{{
+			Name:        "echo",
+			InputSchema: json.RawMessage(`{"type": "object", "properties": {"text": {"type": "string"}}}`),
+			Call: func(ctx context.Context, arguments json.RawMessage) (string, error) {
+				var args struct{ Text string }
+				json.Unmarshal(arguments, &args)
+				if args.Text == "" {
+					return "", fmt.Errorf("text is empty")
+				}
+				return args.Text, nil
+			},
+		}},
+		Resources: testResources{},
+	}
+}
+
+// call sends a request to the server and decodes its result into result, returning the error of the response.
+func call(t *testing.T, s *Server, method string, params string, result any) *rpcError {
+	t.Helper()
+
+	message := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 7, "method": %q, "params": %s}`, method, params)
+	var resp struct {
+		ID     int             `json:"id"`
+		Result json.RawMessage `json:"result"`
+		Error  *rpcError       `json:"error"`
+	}
+	if err := json.Unmarshal(s.Handle(context.Background(), []byte(message)), &resp); err != nil {
+		t.Fatalf("Failed to decode the response to %s: %v", method, err)
+	}
+	if resp.ID != 7 {
+		t.Errorf("Expected the id of the request in the response to %s, got %d", method, resp.ID)
+	}
+	if resp.Error == nil && result != nil {
+		if err := json.Unmarshal(resp.Result, result); err != nil {
+			t.Fatalf("Failed to decode the result of %s: %v", method, err)
+		}
+	}
+	return resp.Error
+}
+
+func TestHandle(t *testing.T) {
+	s := newTestServer()
+
+	var initialized struct {
+		ProtocolVersion string         `json:"protocolVersion"`
+		Capabilities    map[string]any `json:"capabilities"`
+		ServerInfo      struct{ Name string }
+	}
+	call(t, s, "initialize", `{"protocolVersion": "2024-11-05", "capabilities": {}}`, &initialized)
+	if initialized.ProtocolVersion != "2024-11-05" || initialized.ServerInfo.Name != "test" || initialized.Capabilities["resources"] == nil {
+		t.Errorf("Unexpected initialization %+v", initialized)
+	}
+	call(t, s, "initialize", `{"protocolVersion": "1999-01-01"}`, &initialized)
+	if initialized.ProtocolVersion != ProtocolVersion {
+		t.Errorf("Expected the latest version for an unknown one, got %s", initialized.ProtocolVersion)
+	}
+
+	var tools struct{ Tools []map[string]any }
+	call(t, s, "tools/list", `{}`, &tools)
+	if len(tools.Tools) != 1 || tools.Tools[0]["name"] != "echo" || tools.Tools[0]["inputSchema"] == nil {
+		t.Errorf("Unexpected tools %v", tools.Tools)
+	}
+
+	var result callResult
+	call(t, s, "tools/call", `{"name": "echo", "arguments": {"text": "hello"}}`, &result)
+	if result.IsError || len(result.Content) != 1 || result.Content[0].Text != "hello" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	call(t, s, "tools/call", `{"name": "echo"}`, &result)
+	if !result.IsError || result.Content[0].Text != "text is empty" {
+		t.Errorf("Expected the error of the tool as its result, got %+v", result)
+	}
+	if err := call(t, s, "tools/call", `{"name": "missing"}`, nil); err == nil || err.Code != codeInvalidParams {
+		t.Errorf("Expected an invalid params error for an unknown tool, got %v", err)
+	}
+
+	var read struct{ Contents []ResourceContents }
+	call(t, s, "resources/read", `{"uri": "test://a"}`, &read)
+	if len(read.Contents) != 1 || read.Contents[0].Text != "content of a" {
+		t.Errorf("Unexpected contents %+v", read.Contents)
+	}
+	if err := call(t, s, "resources/read", `{"uri": "test://b"}`, nil); err == nil || err.Code != codeResourceNotFound {
+		t.Errorf("Expected a resource not found error, got %v", err)
+	}
+
+	if err := call(t, s, "prompts/list", `{}`, nil); err == nil || err.Code != codeMethodNotFound {
+		t.Errorf("Expected a method not found error, got %v", err)
+	}
+
+	if reply := s.Handle(context.Background(), []byte(`{"jsonrpc": "2.0", "method": "notifications/initialized"}`)); reply != nil {
+		t.Errorf("Expected no reply to a notification, got %s", reply)
+	}
+	if reply := s.Handle(context.Background(), []byte(`{"jsonrpc": `)); !bytes.Contains(reply, []byte(`"code":-32700`)) {
+		t.Errorf("Expected a parse error, got %s", reply)
+	}
+}
+
+func TestServeStdio(t *testing.T) {
+	input := strings.Join([]string{
+		`{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": {"protocolVersion": "2025-06-18"}}`,
+		`{"jsonrpc": "2.0", "method": "notifications/initialized"}`,
+		``,
+		`{"jsonrpc": "2.0", "id": "two", "method": "ping"}`,
+	}, "\n")
+
+	var output bytes.Buffer
+	if err := newTestServer().ServeStdio(context.Background(), strings.NewReader(input), &output); err != nil {
+		t.Fatalf("ServeStdio() returned unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"id":1`) || lines[1] != `{"jsonrpc":"2.0","id":"two","result":{}}` {
+		t.Errorf("Unexpected output:\n%s", output.String())
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	server := httptest.NewServer(newTestServer())
+	defer server.Close()
+
+	post := func(body string, origin string) *http.Response {
+		req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json, text/event-stream")
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to post: %v", err)
+		}
+		return resp
+	}
+
+	resp := post(`{"jsonrpc": "2.0", "id": 1, "method": "ping"}`, "http://localhost:3000")
+	defer resp.Body.Close()
+	var body struct{ Result map[string]any }
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "application/json" || json.NewDecoder(resp.Body).Decode(&body) != nil || body.Result == nil {
+		t.Errorf("Unexpected response %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	if resp := post(`{"jsonrpc": "2.0", "method": "notifications/initialized"}`, ""); resp.StatusCode != http.StatusAccepted {
+		t.Errorf("Expected notifications to be accepted, got %d", resp.StatusCode)
+	}
+
+	if resp := post(`{"jsonrpc": "2.0", "id": 1, "method": "ping"}`, "http://evil.example.com"); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected requests from other origins to be forbidden, got %d", resp.StatusCode)
+	}
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to get: %v", err)
+	}
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("Expected GET not to be allowed, got %d", resp.StatusCode)
+	}
+}
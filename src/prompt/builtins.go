@@ -0,0 +1,81 @@
+package prompt
+
+import (
+	"sort"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+const DefaultTemplate = "default"
+
+const citeSources = `Cite the chunks you rely on by their numbers in brackets, eg. [2]. If the context does not hold what is needed, say so rather than guessing.`
+
+const questionWithContext = `Context:
+
+{{.Context}}
+Question: {{.Question}}
+`
+
+const fencedChunk = "{{.Citation}}\n```{{lang .Name}}\n{{trim .Text}}\n```"
+
+var builtinDict = map[string]*pb.PromptTemplate{
+	DefaultTemplate: {
+		Name:        DefaultTemplate,
+		Description: "Answers questions with the context, citing its sources",
+		System:      `You answer questions and write code or queries using the context below, taken from an index of documents, code and data. ` + citeSources,
+		Prompt:      questionWithContext,
+	},
+	"sql": {
+		Name:        "sql",
+		Description: "Writes a SQL query against the schemas of the context, replying with SQL only",
+		System: `You write SQL queries against the database schemas, migrations and queries in the context below. ` +
+			`Only use tables and columns that appear in the context. Reply with the SQL only, without code fences, and ` +
+			`explain any assumption in -- comments, citing the chunks you rely on by their numbers in brackets, eg. -- [2].`,
+		Prompt: questionWithContext,
+		ChunkByType: map[string]string{
+			"TEXT": fencedChunk,
+		},
+	},
+	"api-client": {
+		Name:        "api-client",
+		Description: "Writes client code calling the APIs described by the OpenAPI and protobuf definitions of the context",
+		System: `You write client code calling the APIs described in the context below, such as OpenAPI documents and ` +
+			`protobuf services. Use the exact paths, methods, messages and fields of the definitions, and handle their ` +
+			`errors. Reply with the code in a single fenced block, in the language asked for or Go otherwise, followed ` +
+			`by a short explanation. ` + citeSources,
+		Prompt: questionWithContext,
+		ChunkByType: map[string]string{
+			"OPENAPI": fencedChunk,
+			"PROTO":   fencedChunk,
+			"JSON":    fencedChunk,
+			"YAML":    fencedChunk,
+		},
+	},
+	"explain": {
+		Name:        "explain",
+		Description: "Explains how the code of the context works",
+		System: `You explain code to a developer new to it: what it does, how its parts fit together and why, using the ` +
+			`code and documentation in the context below. Refer to functions and types by name. ` + citeSources,
+		Prompt: questionWithContext,
+		Chunk:  fencedChunk,
+		ChunkByType: map[string]string{
+			"MARKDOWN": DefaultChunk,
+			"PDF":      DefaultChunk,
+		},
+	},
+}
+
+// Builtin returns the built-in template with the given name, or nil if there is none.
+func Builtin(name string) *pb.PromptTemplate {
+	return builtinDict[name]
+}
+
+// Builtins returns the built-in templates, sorted by name.
+func Builtins() []*pb.PromptTemplate {
+	templates := make([]*pb.PromptTemplate, 0, len(builtinDict))
+	for _, t := range builtinDict {
+		templates = append(templates, t)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates
+}
@@ -0,0 +1,192 @@
+package prompt
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"text/template"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+const (
+	DefaultChunk = "{{.Citation}}\n{{.Text}}"
+
+	// TruncationMarker ends the rendering of chunks whose text was cut short
+	TruncationMarker = "[... truncated]\n"
+)
+
+// Data is what the system prompt and the user message of a template are executed with.
+type Data struct {
+	Question  string
+	Context   string
+	Citations []*pb.Citation
+}
+
+// Chunk is what chunk renderings are executed with.
+type Chunk struct {
+	Number    int32
+	Name      string
+	StartLine int32
+	EndLine   int32
+	Path      string
+	DataType  string
+	Truncated bool
+	// Citation is the formatted citation, eg. "[2] docs/guide.md, lines 3-9 (Install)"
+	Citation string
+	Text     string
+}
+
+func newChunk(citation *pb.Citation, text string) *Chunk {
+	return &Chunk{
+		Number:    citation.Number,
+		Name:      citation.Name,
+		StartLine: citation.StartLine,
+		EndLine:   citation.EndLine,
+		Path:      citation.Path,
+		DataType:  citation.DataType,
+		Truncated: citation.Truncated,
+		Citation:  FormatCitation(citation),
+		Text:      text,
+	}
+}
+
+var funcs = template.FuncMap{
+	"lang":  Language,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"trim":  strings.TrimSpace,
+}
+
+// Template is a parsed PromptTemplate.
+type Template struct {
+	Definition *pb.PromptTemplate
+
+	system      *template.Template
+	prompt      *template.Template
+	chunk       *template.Template
+	chunkByType map[string]*template.Template
+}
+
+// Parse parses the templates of a definition, and executes them with sample data so that templates referring to
+// unknown fields fail now rather than when a question is asked.
+func Parse(definition *pb.PromptTemplate) (*Template, error) {
+	t := &Template{Definition: definition, chunkByType: make(map[string]*template.Template)}
+
+	parse := func(name string, text string) (*template.Template, error) {
+		parsed, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the %s template: %v", name, err)
+		}
+		return parsed, nil
+	}
+
+	var err error
+	if t.system, err = parse("system", definition.System); err != nil {
+		return nil, err
+	}
+	if t.prompt, err = parse("prompt", definition.Prompt); err != nil {
+		return nil, err
+	}
+
+	chunk := definition.Chunk
+	if chunk == "" {
+		chunk = DefaultChunk
+	}
+	if t.chunk, err = parse("chunk", chunk); err != nil {
+		return nil, err
+	}
+	for dataType, text := range definition.ChunkByType {
+		if t.chunkByType[dataType], err = parse("chunk of "+dataType, text); err != nil {
+			return nil, err
+		}
+	}
+
+	sample := &pb.Citation{Number: 1, Name: "docs/guide.md", StartLine: 1, EndLine: 2, DataType: "MARKDOWN"}
+	data := &Data{Question: "question", Context: "context", Citations: []*pb.Citation{sample}}
+	for _, tmpl := range []*template.Template{t.system, t.prompt} {
+		if err := tmpl.Execute(io.Discard, data); err != nil {
+			return nil, fmt.Errorf("failed to execute the %s template: %v", tmpl.Name(), err)
+		}
+	}
+	for _, tmpl := range append([]*template.Template{t.chunk}, values(t.chunkByType)...) {
+		if err := tmpl.Execute(io.Discard, newChunk(sample, "text")); err != nil {
+			return nil, fmt.Errorf("failed to execute the %s template: %v", tmpl.Name(), err)
+		}
+	}
+
+	return t, nil
+}
+
+func values(m map[string]*template.Template) []*template.Template {
+	var templates []*template.Template
+	for _, t := range m {
+		templates = append(templates, t)
+	}
+	return templates
+}
+
+// System renders the system prompt.
+func (t *Template) System(data *Data) (string, error) {
+	return execute(t.system, data)
+}
+
+// Prompt renders the user message.
+func (t *Template) Prompt(data *Data) (string, error) {
+	return execute(t.prompt, data)
+}
+
+// Block renders a chunk of a context with the rendering for its type, or the default one. Blocks end with a line
+// break, followed by TruncationMarker if the chunk was truncated.
+func (t *Template) Block(citation *pb.Citation, text string) (string, error) {
+	tmpl, ok := t.chunkByType[citation.DataType]
+	if !ok {
+		tmpl = t.chunk
+	}
+
+	block, err := execute(tmpl, newChunk(citation, text))
+	if err != nil {
+		return "", err
+	}
+
+	if !strings.HasSuffix(block, "\n") {
+		block += "\n"
+	}
+	if citation.Truncated {
+		block += TruncationMarker
+	}
+	return block, nil
+}
+
+func execute(tmpl *template.Template, data any) (string, error) {
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to execute the %s template: %v", tmpl.Name(), err)
+	}
+	return sb.String(), nil
+}
+
+// FormatCitation formats a citation as "[2] docs/guide.md, lines 3-9 (Install)".
+func FormatCitation(citation *pb.Citation) string {
+	formatted := fmt.Sprintf("[%d] %s", citation.Number, citation.Name)
+	if citation.StartLine > 0 {
+		formatted += fmt.Sprintf(", lines %d-%d", citation.StartLine, citation.EndLine)
+	}
+	if citation.Path != "" {
+		formatted += fmt.Sprintf(" (%s)", citation.Path)
+	}
+	return formatted
+}
+
+var languages = map[string]string{
+	".go": "go", ".py": "python", ".js": "javascript", ".ts": "typescript", ".java": "java", ".rs": "rust",
+	".c": "c", ".h": "c", ".cc": "cpp", ".cpp": "cpp", ".rb": "ruby", ".sh": "bash", ".sql": "sql",
+	".proto": "proto", ".json": "json", ".yaml": "yaml", ".yml": "yaml", ".toml": "toml", ".md": "markdown",
+}
+
+// Language returns the name of the language of a file for fenced code blocks, eg. "go" for "main.go", or "" if
+// it is not known.
+func Language(name string) string {
+	return languages[strings.ToLower(path.Ext(name))]
+}
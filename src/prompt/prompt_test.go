@@ -0,0 +1,92 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+func TestParse_Builtins(t *testing.T) {
+	for _, definition := range Builtins() {
+		if _, err := Parse(definition); err != nil {
+			t.Errorf("Parse(%s) returned unexpected error: %v", definition.Name, err)
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition *pb.PromptTemplate
+	}{
+		{"syntax", &pb.PromptTemplate{Prompt: "{{.Question"}},
+		{"unknown field", &pb.PromptTemplate{System: "{{.Answer}}"}},
+		{"unknown chunk field", &pb.PromptTemplate{ChunkByType: map[string]string{"PROTO": "{{.Question}}"}}},
+		{"unknown function", &pb.PromptTemplate{Chunk: "{{shout .Text}}"}},
+	}
+
+	for _, tt := range tests {
+		if _, err := Parse(tt.definition); err == nil {
+			t.Errorf("%s: expected an error, but got nil", tt.name)
+		}
+	}
+}
+
+func TestBlock(t *testing.T) {
+	tmpl, err := Parse(Builtin("explain"))
+	if err != nil {
+		t.Fatalf("Parse() returned unexpected error: %v", err)
+	}
+
+	code := &pb.Citation{Number: 1, Name: "server/main.go", StartLine: 3, EndLine: 4, Path: "main", DataType: "TEXT"}
+	block, err := tmpl.Block(code, "func main() {\n}\n")
+	if err != nil {
+		t.Fatalf("Block() returned unexpected error: %v", err)
+	}
+	if expected := "[1] server/main.go, lines 3-4 (main)\n```go\nfunc main() {\n}\n```\n"; block != expected {
+		t.Errorf("Expected the code to be fenced as go, got:\n%s", block)
+	}
+
+	doc := &pb.Citation{Number: 2, Name: "README.md", DataType: "MARKDOWN", Truncated: true}
+	block, err = tmpl.Block(doc, "# Usage")
+	if err != nil {
+		t.Fatalf("Block() returned unexpected error: %v", err)
+	}
+	if expected := "[2] README.md\n# Usage\n" + TruncationMarker; block != expected {
+		t.Errorf("Expected the markdown to be rendered by its type, got:\n%s", block)
+	}
+}
+
+func TestSystemAndPrompt(t *testing.T) {
+	tmpl, err := Parse(&pb.PromptTemplate{
+		System: "Answer with {{len .Citations}} sources.",
+		Prompt: "{{upper .Question}}\n{{range .Citations}}{{.Name}} {{end}}",
+	})
+	if err != nil {
+		t.Fatalf("Parse() returned unexpected error: %v", err)
+	}
+
+	data := &Data{Question: "why?", Citations: []*pb.Citation{{Name: "a.md"}, {Name: "b.go"}}}
+	system, _ := tmpl.System(data)
+	user, _ := tmpl.Prompt(data)
+	if system != "Answer with 2 sources." || !strings.HasPrefix(user, "WHY?\na.md b.go") {
+		t.Errorf("Unexpected rendering %q, %q", system, user)
+	}
+}
+
+func TestLanguage(t *testing.T) {
+	tests := map[string]string{
+		"main.go":        "go",
+		"db/Schema.SQL":  "sql",
+		"api.proto":      "proto",
+		"notes":          "",
+		"archive.tar.gz": "",
+	}
+
+	for name, expected := range tests {
+		if got := Language(name); got != expected {
+			t.Errorf("Language(%q) = %q, expected %q", name, got, expected)
+		}
+	}
+}
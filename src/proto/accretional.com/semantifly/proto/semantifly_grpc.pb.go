@@ -19,11 +19,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Semantifly_Add_FullMethodName           = "/semantifly.Semantifly/Add"
-	Semantifly_Delete_FullMethodName        = "/semantifly.Semantifly/Delete"
-	Semantifly_Get_FullMethodName           = "/semantifly.Semantifly/Get"
-	Semantifly_Update_FullMethodName        = "/semantifly.Semantifly/Update"
-	Semantifly_LexicalSearch_FullMethodName = "/semantifly.Semantifly/LexicalSearch"
+	Semantifly_Add_FullMethodName            = "/semantifly.Semantifly/Add"
+	Semantifly_Delete_FullMethodName         = "/semantifly.Semantifly/Delete"
+	Semantifly_Get_FullMethodName            = "/semantifly.Semantifly/Get"
+	Semantifly_Update_FullMethodName         = "/semantifly.Semantifly/Update"
+	Semantifly_LexicalSearch_FullMethodName  = "/semantifly.Semantifly/LexicalSearch"
+	Semantifly_AddType_FullMethodName        = "/semantifly.Semantifly/AddType"
+	Semantifly_DescribeData_FullMethodName   = "/semantifly.Semantifly/DescribeData"
+	Semantifly_DescribeType_FullMethodName   = "/semantifly.Semantifly/DescribeType"
+	Semantifly_List_FullMethodName           = "/semantifly.Semantifly/List"
+	Semantifly_Embed_FullMethodName          = "/semantifly.Semantifly/Embed"
+	Semantifly_SemanticSearch_FullMethodName = "/semantifly.Semantifly/SemanticSearch"
+	Semantifly_Context_FullMethodName        = "/semantifly.Semantifly/Context"
+	Semantifly_Generate_FullMethodName       = "/semantifly.Semantifly/Generate"
 )
 
 // SemantiflyClient is the client API for Semantifly service.
@@ -35,6 +43,14 @@ type SemantiflyClient interface {
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
 	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
 	LexicalSearch(ctx context.Context, in *LexicalSearchRequest, opts ...grpc.CallOption) (*LexicalSearchResponse, error)
+	AddType(ctx context.Context, in *AddTypeRequest, opts ...grpc.CallOption) (*AddTypeResponse, error)
+	DescribeData(ctx context.Context, in *DescribeDataRequest, opts ...grpc.CallOption) (*DescribeDataResponse, error)
+	DescribeType(ctx context.Context, in *DescribeTypeRequest, opts ...grpc.CallOption) (*DescribeTypeResponse, error)
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	Embed(ctx context.Context, in *EmbedRequest, opts ...grpc.CallOption) (*EmbedResponse, error)
+	SemanticSearch(ctx context.Context, in *SemanticSearchRequest, opts ...grpc.CallOption) (*SemanticSearchResponse, error)
+	Context(ctx context.Context, in *ContextRequest, opts ...grpc.CallOption) (*ContextResponse, error)
+	Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error)
 }
 
 type semantiflyClient struct {
@@ -95,6 +111,86 @@ func (c *semantiflyClient) LexicalSearch(ctx context.Context, in *LexicalSearchR
 	return out, nil
 }
 
+func (c *semantiflyClient) AddType(ctx context.Context, in *AddTypeRequest, opts ...grpc.CallOption) (*AddTypeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddTypeResponse)
+	err := c.cc.Invoke(ctx, Semantifly_AddType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *semantiflyClient) DescribeData(ctx context.Context, in *DescribeDataRequest, opts ...grpc.CallOption) (*DescribeDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DescribeDataResponse)
+	err := c.cc.Invoke(ctx, Semantifly_DescribeData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *semantiflyClient) DescribeType(ctx context.Context, in *DescribeTypeRequest, opts ...grpc.CallOption) (*DescribeTypeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DescribeTypeResponse)
+	err := c.cc.Invoke(ctx, Semantifly_DescribeType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *semantiflyClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListResponse)
+	err := c.cc.Invoke(ctx, Semantifly_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *semantiflyClient) Embed(ctx context.Context, in *EmbedRequest, opts ...grpc.CallOption) (*EmbedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmbedResponse)
+	err := c.cc.Invoke(ctx, Semantifly_Embed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *semantiflyClient) SemanticSearch(ctx context.Context, in *SemanticSearchRequest, opts ...grpc.CallOption) (*SemanticSearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SemanticSearchResponse)
+	err := c.cc.Invoke(ctx, Semantifly_SemanticSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *semantiflyClient) Context(ctx context.Context, in *ContextRequest, opts ...grpc.CallOption) (*ContextResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ContextResponse)
+	err := c.cc.Invoke(ctx, Semantifly_Context_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *semantiflyClient) Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateResponse)
+	err := c.cc.Invoke(ctx, Semantifly_Generate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SemantiflyServer is the server API for Semantifly service.
 // All implementations must embed UnimplementedSemantiflyServer
 // for forward compatibility.
@@ -104,6 +200,14 @@ type SemantiflyServer interface {
 	Get(context.Context, *GetRequest) (*GetResponse, error)
 	Update(context.Context, *UpdateRequest) (*UpdateResponse, error)
 	LexicalSearch(context.Context, *LexicalSearchRequest) (*LexicalSearchResponse, error)
+	AddType(context.Context, *AddTypeRequest) (*AddTypeResponse, error)
+	DescribeData(context.Context, *DescribeDataRequest) (*DescribeDataResponse, error)
+	DescribeType(context.Context, *DescribeTypeRequest) (*DescribeTypeResponse, error)
+	List(context.Context, *ListRequest) (*ListResponse, error)
+	Embed(context.Context, *EmbedRequest) (*EmbedResponse, error)
+	SemanticSearch(context.Context, *SemanticSearchRequest) (*SemanticSearchResponse, error)
+	Context(context.Context, *ContextRequest) (*ContextResponse, error)
+	Generate(context.Context, *GenerateRequest) (*GenerateResponse, error)
 	mustEmbedUnimplementedSemantiflyServer()
 }
 
@@ -129,6 +233,30 @@ func (UnimplementedSemantiflyServer) Update(context.Context, *UpdateRequest) (*U
 func (UnimplementedSemantiflyServer) LexicalSearch(context.Context, *LexicalSearchRequest) (*LexicalSearchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LexicalSearch not implemented")
 }
+func (UnimplementedSemantiflyServer) AddType(context.Context, *AddTypeRequest) (*AddTypeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddType not implemented")
+}
+func (UnimplementedSemantiflyServer) DescribeData(context.Context, *DescribeDataRequest) (*DescribeDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeData not implemented")
+}
+func (UnimplementedSemantiflyServer) DescribeType(context.Context, *DescribeTypeRequest) (*DescribeTypeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeType not implemented")
+}
+func (UnimplementedSemantiflyServer) List(context.Context, *ListRequest) (*ListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedSemantiflyServer) Embed(context.Context, *EmbedRequest) (*EmbedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Embed not implemented")
+}
+func (UnimplementedSemantiflyServer) SemanticSearch(context.Context, *SemanticSearchRequest) (*SemanticSearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SemanticSearch not implemented")
+}
+func (UnimplementedSemantiflyServer) Context(context.Context, *ContextRequest) (*ContextResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Context not implemented")
+}
+func (UnimplementedSemantiflyServer) Generate(context.Context, *GenerateRequest) (*GenerateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Generate not implemented")
+}
 func (UnimplementedSemantiflyServer) mustEmbedUnimplementedSemantiflyServer() {}
 func (UnimplementedSemantiflyServer) testEmbeddedByValue()                    {}
 
@@ -240,6 +368,150 @@ func _Semantifly_LexicalSearch_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Semantifly_AddType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SemantiflyServer).AddType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Semantifly_AddType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SemantiflyServer).AddType(ctx, req.(*AddTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Semantifly_DescribeData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SemantiflyServer).DescribeData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Semantifly_DescribeData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SemantiflyServer).DescribeData(ctx, req.(*DescribeDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Semantifly_DescribeType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SemantiflyServer).DescribeType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Semantifly_DescribeType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SemantiflyServer).DescribeType(ctx, req.(*DescribeTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Semantifly_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SemantiflyServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Semantifly_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SemantiflyServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Semantifly_Embed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmbedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SemantiflyServer).Embed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Semantifly_Embed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SemantiflyServer).Embed(ctx, req.(*EmbedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Semantifly_SemanticSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SemanticSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SemantiflyServer).SemanticSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Semantifly_SemanticSearch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SemantiflyServer).SemanticSearch(ctx, req.(*SemanticSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Semantifly_Context_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContextRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SemantiflyServer).Context(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Semantifly_Context_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SemantiflyServer).Context(ctx, req.(*ContextRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Semantifly_Generate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SemantiflyServer).Generate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Semantifly_Generate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SemantiflyServer).Generate(ctx, req.(*GenerateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Semantifly_ServiceDesc is the grpc.ServiceDesc for Semantifly service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,6 +539,38 @@ var Semantifly_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "LexicalSearch",
 			Handler:    _Semantifly_LexicalSearch_Handler,
 		},
+		{
+			MethodName: "AddType",
+			Handler:    _Semantifly_AddType_Handler,
+		},
+		{
+			MethodName: "DescribeData",
+			Handler:    _Semantifly_DescribeData_Handler,
+		},
+		{
+			MethodName: "DescribeType",
+			Handler:    _Semantifly_DescribeType_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _Semantifly_List_Handler,
+		},
+		{
+			MethodName: "Embed",
+			Handler:    _Semantifly_Embed_Handler,
+		},
+		{
+			MethodName: "SemanticSearch",
+			Handler:    _Semantifly_SemanticSearch_Handler,
+		},
+		{
+			MethodName: "Context",
+			Handler:    _Semantifly_Context_Handler,
+		},
+		{
+			MethodName: "Generate",
+			Handler:    _Semantifly_Generate_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "semantifly.proto",
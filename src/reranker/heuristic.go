@@ -0,0 +1,131 @@
+package reranker
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kljensen/snowball/english"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+const DefaultRecencyHalfLifeDays = 30
+
+var wordRegex = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// heuristicReranker scores documents without a model. Relevance is the share of the query's stemmed terms a
+// document contains, raised when they occur close together: a document holding all terms within a window as
+// long as the number of terms scores 1. Recency decays exponentially with the age of the document, and takes
+// recencyWeight of the score.
+type heuristicReranker struct {
+	recencyWeight float64
+	halfLife      time.Duration
+	now           func() time.Time
+}
+
+func newHeuristicReranker(config *pb.RerankConfig) (Reranker, error) {
+	recencyWeight := float64(config.GetRecencyWeight())
+	if recencyWeight < 0 || recencyWeight > 1 {
+		return nil, fmt.Errorf("recency weight must be between 0 and 1, got %g", recencyWeight)
+	}
+
+	halfLifeDays := float64(config.GetRecencyHalfLifeDays())
+	if halfLifeDays < 0 {
+		return nil, fmt.Errorf("invalid recency half-life of %g days", halfLifeDays)
+	}
+	if halfLifeDays == 0 {
+		halfLifeDays = DefaultRecencyHalfLifeDays
+	}
+
+	return &heuristicReranker{
+		recencyWeight: recencyWeight,
+		halfLife:      time.Duration(halfLifeDays * float64(24*time.Hour)),
+		now:           time.Now,
+	}, nil
+}
+
+func (h *heuristicReranker) Name() string {
+	return "heuristic"
+}
+
+func (h *heuristicReranker) Rerank(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	queryTerms := make(map[string]bool)
+	for _, term := range terms(query) {
+		queryTerms[term] = true
+	}
+
+	now := h.now()
+	scores := make([]float64, len(documents))
+	for i, document := range documents {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		scores[i] = (1 - h.recencyWeight) * relevance(queryTerms, terms(document.Text))
+		if !document.Updated.IsZero() && h.recencyWeight > 0 {
+			age := max(now.Sub(document.Updated), 0)
+			scores[i] += h.recencyWeight * math.Exp2(-float64(age)/float64(h.halfLife))
+		}
+	}
+
+	return scores, nil
+}
+
+// terms returns the stemmed words of a text that are not stop words, in order.
+func terms(text string) []string {
+	var terms []string
+	for _, word := range wordRegex.FindAllString(strings.ToLower(text), -1) {
+		if english.IsStopWord(word) {
+			continue
+		}
+		terms = append(terms, english.Stem(word, false))
+	}
+	return terms
+}
+
+// relevance is the share of query terms found in the document, halved when they are spread out. Proximity is the
+// number of distinct terms found over the length of the shortest window of the document containing them all.
+func relevance(queryTerms map[string]bool, documentTerms []string) float64 {
+	if len(queryTerms) == 0 {
+		return 0
+	}
+
+	found := make(map[string]bool)
+	for _, term := range documentTerms {
+		if queryTerms[term] {
+			found[term] = true
+		}
+	}
+	if len(found) == 0 {
+		return 0
+	}
+
+	// shortest window containing every found term, by sliding its start after each end
+	window := len(documentTerms)
+	counts := make(map[string]int)
+	start := 0
+	for end, term := range documentTerms {
+		if !found[term] {
+			continue
+		}
+		counts[term]++
+		for len(counts) == len(found) {
+			window = min(window, end-start+1)
+			if first := documentTerms[start]; found[first] {
+				counts[first]--
+				if counts[first] == 0 {
+					delete(counts, first)
+				}
+			}
+			start++
+		}
+	}
+
+	coverage := float64(len(found)) / float64(len(queryTerms))
+	proximity := float64(len(found)) / float64(window)
+	return coverage * (1 + proximity) / 2
+}
@@ -0,0 +1,210 @@
+package reranker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+const DefaultAPIKeyEnv = "RERANK_API_KEY"
+
+// httpReranker is a client of a rerank endpoint, which scores query and document pairs with a cross-encoder.
+// The tei and openai providers differ only in the shape of the request and response bodies.
+type httpReranker struct {
+	client   *http.Client
+	endpoint string
+	apiKey   string
+	model    string
+	provider string
+}
+
+type teiRequest struct {
+	Query string   `json:"query"`
+	Texts []string `json:"texts"`
+}
+
+type teiResponse []struct {
+	Index int     `json:"index"`
+	Score float64 `json:"score"`
+}
+
+type openAIRequest struct {
+	Model     string   `json:"model,omitempty"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+	TopN      int      `json:"top_n"`
+}
+
+type openAIResponse struct {
+	Results []struct {
+		Index          int     `json:"index"`
+		RelevanceScore float64 `json:"relevance_score"`
+	} `json:"results"`
+}
+
+func newTEIReranker(config *pb.RerankConfig) (Reranker, error) {
+	return newHTTPReranker("tei", config)
+}
+
+func newOpenAIReranker(config *pb.RerankConfig) (Reranker, error) {
+	return newHTTPReranker("openai", config)
+}
+
+func newHTTPReranker(provider string, config *pb.RerankConfig) (Reranker, error) {
+	if config.GetBaseUrl() == "" {
+		return nil, fmt.Errorf("the %s reranker requires a base URL", provider)
+	}
+
+	apiKeyEnv := config.GetApiKeyEnv()
+	if apiKeyEnv == "" {
+		apiKeyEnv = DefaultAPIKeyEnv
+	}
+
+	return &httpReranker{
+		client:   &http.Client{Timeout: 2 * time.Minute},
+		endpoint: strings.TrimSuffix(config.GetBaseUrl(), "/") + "/rerank",
+		apiKey:   os.Getenv(apiKeyEnv),
+		model:    config.GetModel(),
+		provider: provider,
+	}, nil
+}
+
+func (h *httpReranker) Name() string {
+	if h.model == "" {
+		return h.provider
+	}
+	return h.provider + ":" + h.model
+}
+
+func (h *httpReranker) Rerank(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	texts := make([]string, len(documents))
+	for i, document := range documents {
+		texts[i] = document.Text
+	}
+
+	var request any = &teiRequest{Query: query, Texts: texts}
+	if h.provider == "openai" {
+		request = &openAIRequest{Model: h.model, Query: query, Documents: texts, TopN: len(texts)}
+	}
+
+	data, err := h.post(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// scores are matched to documents by index, since results come sorted by score
+	scores := make([]float64, len(documents))
+	seen := make([]bool, len(documents))
+	set := func(index int, score float64) error {
+		if index < 0 || index >= len(documents) || seen[index] {
+			return fmt.Errorf("rerank API returned an invalid index %d", index)
+		}
+		scores[index], seen[index] = score, true
+		return nil
+	}
+
+	switch h.provider {
+	case "openai":
+		parsed := &openAIResponse{}
+		if err := json.Unmarshal(data, parsed); err != nil {
+			return nil, fmt.Errorf("failed to parse rerank response: %v", err)
+		}
+		for _, result := range parsed.Results {
+			if err := set(result.Index, result.RelevanceScore); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		parsed := teiResponse{}
+		if err := json.Unmarshal(data, &parsed); err != nil {
+			return nil, fmt.Errorf("failed to parse rerank response: %v", err)
+		}
+		for _, result := range parsed {
+			if err := set(result.Index, result.Score); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for i := range seen {
+		if !seen[i] {
+			return nil, fmt.Errorf("rerank API returned no score for document %d", i)
+		}
+	}
+
+	return scores, nil
+}
+
+func (h *httpReranker) post(ctx context.Context, request any) ([]byte, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal rerank request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", h.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if h.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+h.apiKey)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request reranking: %v", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rerank response: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if message := errorMessage(data); message != "" {
+			return nil, fmt.Errorf("rerank API returned %s: %s", resp.Status, message)
+		}
+		return nil, fmt.Errorf("rerank API returned %s", resp.Status)
+	}
+
+	return data, nil
+}
+
+// errorMessage extracts the message of an error response, which is {"error": "..."} for tei and
+// {"error": {"message": "..."}} or {"detail": "..."} for the other servers.
+func errorMessage(data []byte) string {
+	var parsed struct {
+		Error  json.RawMessage `json:"error"`
+		Detail string          `json:"detail"`
+	}
+	if json.Unmarshal(data, &parsed) != nil {
+		return ""
+	}
+
+	var message string
+	if json.Unmarshal(parsed.Error, &message) == nil && message != "" {
+		return message
+	}
+
+	var nested struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(parsed.Error, &nested) == nil && nested.Message != "" {
+		return nested.Message
+	}
+
+	return parsed.Detail
+}
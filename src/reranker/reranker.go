@@ -0,0 +1,83 @@
+package reranker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+const DefaultTopK = 50
+
+// Document is a result of first-stage retrieval to rerank.
+type Document struct {
+	Text string
+	// When the content of the document was last refreshed, zero if unknown
+	Updated time.Time
+}
+
+// Reranker scores documents against a query, more precisely but more slowly than first-stage retrieval.
+type Reranker interface {
+	// Rerank returns one score per document, in order, higher meaning more relevant.
+	Rerank(ctx context.Context, query string, documents []Document) ([]float64, error)
+	// Name identifies the reranker and its model.
+	Name() string
+}
+
+type ProviderInfo struct {
+	Description string
+	New         func(config *pb.RerankConfig) (Reranker, error)
+}
+
+var providerDict = map[string]ProviderInfo{
+	"heuristic": {
+		Description: "Built-in: how many of the query's terms a document contains and how close together, and how recent it is",
+		New:         newHeuristicReranker,
+	},
+	"tei": {
+		Description: "The /rerank endpoint of text-embeddings-inference, serving a cross-encoder",
+		New:         newTEIReranker,
+	},
+	"openai": {
+		Description: "Any server implementing the /v1/rerank API of Cohere and Jina, eg. llama.cpp or vLLM",
+		New:         newOpenAIReranker,
+	},
+}
+
+// Providers returns the names of the available rerankers, sorted.
+func Providers() []string {
+	names := make([]string, 0, len(providerDict))
+	for name := range providerDict {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New creates the Reranker described by config. A nil config or an empty provider results in the heuristic reranker.
+func New(config *pb.RerankConfig) (Reranker, error) {
+	provider := config.GetProvider()
+	if provider == "" {
+		provider = "heuristic"
+	}
+
+	info, ok := providerDict[provider]
+	if !ok {
+		return nil, fmt.Errorf("unknown reranker %s, expected one of %s", provider, strings.Join(Providers(), ", "))
+	}
+
+	return info.New(config)
+}
+
+// Order returns the indexes of the scores from highest to lowest, keeping the original order among equal scores.
+func Order(scores []float64) []int {
+	order := make([]int, len(scores))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return scores[order[i]] > scores[order[j]] })
+	return order
+}
@@ -0,0 +1,139 @@
+package reranker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+func TestNew(t *testing.T) {
+	r, err := New(nil)
+	if err != nil {
+		t.Fatalf("New() returned unexpected error: %v", err)
+	}
+	if r.Name() != "heuristic" {
+		t.Errorf("Expected the heuristic reranker, got %s", r.Name())
+	}
+
+	if _, err := New(&pb.RerankConfig{Provider: "unknown"}); err == nil {
+		t.Error("Expected an error for an unknown provider")
+	}
+	if _, err := New(&pb.RerankConfig{Provider: "tei"}); err == nil {
+		t.Error("Expected an error for a tei reranker without a base URL")
+	}
+	if _, err := New(&pb.RerankConfig{RecencyWeight: 2}); err == nil {
+		t.Error("Expected an error for a recency weight above 1")
+	}
+}
+
+func TestHeuristicReranker(t *testing.T) {
+	r, _ := New(nil)
+
+	documents := []Document{
+		{Text: "The schema of the database is migrated before the users table is created"},
+		{Text: "Recipes for bread"},
+		{Text: "The users database schema lists every column"},
+		{Text: "Users sign in with their email"},
+	}
+	scores, err := r.Rerank(context.Background(), "users database schema", documents)
+	if err != nil {
+		t.Fatalf("Rerank() returned unexpected error: %v", err)
+	}
+
+	// all terms adjacent, then all terms spread out, then one term, then none
+	if order := Order(scores); !reflect.DeepEqual(order, []int{2, 0, 3, 1}) {
+		t.Errorf("Unexpected order %v for scores %v", order, scores)
+	}
+	if scores[2] != 1 || scores[1] != 0 {
+		t.Errorf("Expected scores of 1 for adjacent terms and 0 without terms, got %v", scores)
+	}
+}
+
+func TestHeuristicReranker_Recency(t *testing.T) {
+	r, err := New(&pb.RerankConfig{RecencyWeight: 0.5, RecencyHalfLifeDays: 10})
+	if err != nil {
+		t.Fatalf("New() returned unexpected error: %v", err)
+	}
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	r.(*heuristicReranker).now = func() time.Time { return now }
+
+	documents := []Document{
+		{Text: "deploy the service", Updated: now.AddDate(0, 0, -10)},
+		{Text: "deploy the service", Updated: now},
+		{Text: "deploy the service"},
+	}
+	scores, err := r.Rerank(context.Background(), "deploy service", documents)
+	if err != nil {
+		t.Fatalf("Rerank() returned unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(scores, []float64{0.75, 1, 0.5}) {
+		t.Errorf("Expected recency to decay by half every 10 days, got %v", scores)
+	}
+}
+
+func TestHTTPReranker(t *testing.T) {
+	tests := []struct {
+		provider string
+		response string
+		check    func(body map[string]any) bool
+	}{
+		{
+			provider: "tei",
+			response: `[{"index": 1, "score": 0.9}, {"index": 0, "score": 0.2}]`,
+			check:    func(body map[string]any) bool { return body["texts"] != nil },
+		},
+		{
+			provider: "openai",
+			response: `{"results": [{"index": 1, "relevance_score": 0.9}, {"index": 0, "relevance_score": 0.2}]}`,
+			check:    func(body map[string]any) bool { return body["documents"] != nil && body["model"] == "bge-reranker" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body := map[string]any{}
+				if r.URL.Path != "/v1/rerank" || json.NewDecoder(r.Body).Decode(&body) != nil || !tt.check(body) || body["query"] != "q" {
+					http.Error(w, `{"error": "unexpected request"}`, http.StatusBadRequest)
+					return
+				}
+				w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			r, err := New(&pb.RerankConfig{Provider: tt.provider, Model: "bge-reranker", BaseUrl: server.URL + "/v1/"})
+			if err != nil {
+				t.Fatalf("New() returned unexpected error: %v", err)
+			}
+
+			scores, err := r.Rerank(context.Background(), "q", []Document{{Text: "a"}, {Text: "b"}})
+			if err != nil {
+				t.Fatalf("Rerank() returned unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(scores, []float64{0.2, 0.9}) {
+				t.Errorf("Expected scores in the order of the documents, got %v", scores)
+			}
+		})
+	}
+}
+
+func TestHTTPReranker_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": {"message": "model not loaded"}}`, http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	r, _ := New(&pb.RerankConfig{Provider: "openai", BaseUrl: server.URL})
+	_, err := r.Rerank(context.Background(), "q", []Document{{Text: "a"}})
+	if err == nil || !strings.Contains(err.Error(), "model not loaded") {
+		t.Errorf("Expected the API's error, got %v", err)
+	}
+}
@@ -1,57 +1,176 @@
 package search
 
 import (
+	"crypto/sha256"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/bzick/tokenizer"
 	"github.com/kljensen/snowball"
 
+	"accretional.com/semantifly/chunker"
+	extract "accretional.com/semantifly/extractor"
 	fetch "accretional.com/semantifly/fetcher"
 	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
 )
 
-// createSearchDictionary processes an IndexListEntry by fetching content from its source,
-// tokenizing the content, and populating the WordOccurrences map with word frequencies.
-// It takes a pointer to an IndexListEntry as input and returns an error if any issues occur
-// during content fetching or processing.
-
+// CreateSearchDictionary indexes an IndexListEntry with the default chunking settings. Its content is fetched
+// from the source, split into sections according to the entry's DataType, and tokenized to populate the
+// WordOccurrences map with word frequencies; the size and digest of the content are recorded, and the content is
+// split into chunks. It returns an error if any issues occur during content fetching or processing.
 func CreateSearchDictionary(ile *pb.IndexListEntry) error {
+	return IndexEntry(ile, nil)
+}
+
+// IndexEntry is CreateSearchDictionary with the chunking settings to split the entry's content with.
+func IndexEntry(ile *pb.IndexListEntry, chunking *pb.ChunkingConfig) error {
 	content, err := fetch.FetchFromSource(ile.ContentMetadata.SourceType, ile.ContentMetadata.URI)
 	if err != nil {
 		return fmt.Errorf("failed to open source file: %w", err)
 	}
 
+	ile.Size = int64(len(content))
+	ile.Digest = fmt.Sprintf("sha256:%x", sha256.Sum256(content))
+
 	if len(content) == 0 {
 		return nil
 	}
 
+	sections, err := extract.Extract(ile.ContentMetadata, content)
+	if err != nil {
+		return err
+	}
+	ile.Sections = sections
+
 	fileContent := string(content)
+	if sections != nil {
+		fileContent = extract.JoinSections(sections)
+	}
+
+	ile.WordOccurrences, ile.StemmedWordOccurrences, err = countWords(fileContent)
+	if err != nil {
+		return err
+	}
+
+	// terms such as key paths are only matched exactly, since they would not survive tokenizing or stemming
+	for _, section := range sections {
+		for _, term := range section.Terms {
+			ile.WordOccurrences[term]++
+		}
+	}
+
+	ile.Chunks, err = chunker.Chunk(ile.Name, &chunker.Input{URI: ile.ContentMetadata.URI, Content: content, Sections: sections}, chunking)
+	if err != nil {
+		return fmt.Errorf("failed to chunk content: %w", err)
+	}
+
+	return nil
+}
+
+// MatchingChunks returns the entry's chunks whose text contains the term, either exactly or after stemming.
+// Terms with dots, such as key paths, are matched verbatim as well.
+func MatchingChunks(ile *pb.IndexListEntry, term string) ([]*pb.Chunk, error) {
+	stemmedTerm, err := snowball.Stem(term, "english", true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stem word: %w", err)
+	}
+
+	var chunks []*pb.Chunk
+	for _, chunk := range ile.Chunks {
+		words, stemmedWords, err := countWords(chunk.Text)
+		if err != nil {
+			return nil, err
+		}
+
+		if words[term] > 0 || stemmedWords[stemmedTerm] > 0 || (strings.Contains(term, ".") && strings.Contains(chunk.Text, term)) {
+			chunks = append(chunks, chunk)
+		}
+	}
+
+	return chunks, nil
+}
+
+// ScoreText returns how many times the words of the query occur in the text, counting both exact and stemmed
+// matches as SubcommandLexicalSearch does, so that exact matches rank higher. Words with dots, such as key paths,
+// are counted verbatim.
+func ScoreText(text string, query string) (int32, error) {
+	queryWords, queryStemmedWords, err := countWords(query)
+	if err != nil {
+		return 0, err
+	}
+
+	words, stemmedWords, err := countWords(text)
+	if err != nil {
+		return 0, err
+	}
 
-	ile.WordOccurrences = make(map[string]int32)
-	ile.StemmedWordOccurrences = make(map[string]int32)
+	var score int32
+	for word := range queryWords {
+		score += words[word]
+	}
+	for word := range queryStemmedWords {
+		score += stemmedWords[word]
+	}
+	for _, term := range strings.Fields(query) {
+		if strings.Contains(term, ".") {
+			score += int32(strings.Count(text, term))
+		}
+	}
+
+	return score, nil
+}
+
+// MatchingSections returns the paths of the entry's sections whose text contains the term,
+// either exactly or after stemming, or that have the term among their indexed terms.
+func MatchingSections(ile *pb.IndexListEntry, term string) ([]string, error) {
+	stemmedTerm, err := snowball.Stem(term, "english", true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stem word: %w", err)
+	}
+
+	var paths []string
+	for _, section := range ile.Sections {
+		words, stemmedWords, err := countWords(section.Text)
+		if err != nil {
+			return nil, err
+		}
+
+		if words[term] > 0 || stemmedWords[stemmedTerm] > 0 || slices.Contains(section.Terms, term) {
+			paths = append(paths, section.Path)
+		}
+	}
+
+	return paths, nil
+}
+
+// countWords tokenizes text and returns the number of occurrences of each word, as written and stemmed.
+func countWords(text string) (map[string]int32, map[string]int32, error) {
+	words := make(map[string]int32)
+	stemmedWords := make(map[string]int32)
 
 	parser := tokenizer.New()
 	parser.AllowKeywordUnderscore()
 
 	// parse and tokenize file content
-	stream := parser.ParseString(fileContent)
+	stream := parser.ParseString(text)
 	defer stream.Close()
 
 	for stream.IsValid() {
 		token := stream.CurrentToken()
 		if token.IsNumber() {
-			ile.WordOccurrences[token.ValueString()]++
-			ile.StemmedWordOccurrences[token.ValueString()]++
+			words[token.ValueString()]++
+			stemmedWords[token.ValueString()]++
 		} else if token.IsKeyword() || token.IsString() {
 			stemmedWord, err := snowball.Stem(token.ValueString(), "english", true)
 			if err != nil {
-				return fmt.Errorf("failed to stem word: %w", err)
+				return nil, nil, fmt.Errorf("failed to stem word: %w", err)
 			}
-			ile.WordOccurrences[token.ValueString()]++
-			ile.StemmedWordOccurrences[stemmedWord]++
+			words[token.ValueString()]++
+			stemmedWords[stemmedWord]++
 		}
 		stream.GoNext()
 	}
 
-	return nil
+	return words, stemmedWords, nil
 }
@@ -1,6 +1,8 @@
 package search
 
 import (
+	"crypto/sha256"
+	"fmt"
 	"os"
 	"reflect"
 	"testing"
@@ -147,6 +149,176 @@ func TestCreateSearchDictionary(t *testing.T) {
 			if !reflect.DeepEqual(ile.WordOccurrences, tt.expectedNonStemmed) {
 				t.Errorf("CreateSearchDictionary() WordOccurrences = %v, want %v", ile.WordOccurrences, tt.expectedNonStemmed)
 			}
+
+			expectedDigest := fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(tt.input)))
+			if ile.Size != int64(len(tt.input)) || ile.Digest != expectedDigest {
+				t.Errorf("CreateSearchDictionary() Size, Digest = %d, %s, want %d, %s", ile.Size, ile.Digest, len(tt.input), expectedDigest)
+			}
 		})
 	}
 }
+
+func TestCreateSearchDictionary_Sections(t *testing.T) {
+	tempFile, err := os.CreateTemp("", "test*.proto")
+	if err != nil {
+		t.Fatalf("Failed to create temporary file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	content := `syntax = "proto3";
+package example;
+
+message SearchResponse {
+  repeated string results = 1;
+}
+
+service Searcher {
+  rpc Search(SearchRequest) returns (SearchResponse) {}
+  rpc Count(SearchRequest) returns (CountResponse) {}
+}
+`
+	if err := os.WriteFile(tempFile.Name(), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write to temp file: %v", err)
+	}
+
+	ile := &pb.IndexListEntry{
+		ContentMetadata: &pb.ContentMetadata{
+			URI:      tempFile.Name(),
+			DataType: pb.DataType_PROTO,
+		},
+	}
+
+	if err := CreateSearchDictionary(ile); err != nil {
+		t.Fatalf("CreateSearchDictionary() returned unexpected error: %v", err)
+	}
+
+	if len(ile.Sections) != 4 {
+		t.Fatalf("Expected 4 sections, got %d", len(ile.Sections))
+	}
+
+	// the syntax and package statements are not part of any section
+	if ile.WordOccurrences["syntax"] != 0 {
+		t.Errorf("Expected 'syntax' not to be indexed, got %d occurrences", ile.WordOccurrences["syntax"])
+	}
+
+	sections, err := MatchingSections(ile, "SearchResponse")
+	if err != nil {
+		t.Fatalf("MatchingSections() returned unexpected error: %v", err)
+	}
+
+	expected := []string{"example.SearchResponse", "example.Searcher", "example.Searcher.Search"}
+	if !reflect.DeepEqual(sections, expected) {
+		t.Errorf("MatchingSections() = %v, want %v", sections, expected)
+	}
+}
+
+func TestCreateSearchDictionary_KeyPaths(t *testing.T) {
+	tempFile, err := os.CreateTemp("", "test*.yaml")
+	if err != nil {
+		t.Fatalf("Failed to create temporary file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	content := `kind: Service
+metadata:
+  name: web
+---
+kind: Deployment
+spec:
+  template:
+    spec:
+      containers:
+        - image: nginx:1.25
+`
+	if err := os.WriteFile(tempFile.Name(), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write to temp file: %v", err)
+	}
+
+	ile := &pb.IndexListEntry{
+		ContentMetadata: &pb.ContentMetadata{
+			URI:      tempFile.Name(),
+			DataType: pb.DataType_YAML,
+		},
+	}
+
+	if err := CreateSearchDictionary(ile); err != nil {
+		t.Fatalf("CreateSearchDictionary() returned unexpected error: %v", err)
+	}
+
+	for _, term := range []string{"spec.template.spec.containers[0].image", "spec.template.spec.containers.image", "metadata.name"} {
+		if ile.WordOccurrences[term] != 1 {
+			t.Errorf("Expected key path %q to be indexed once, got %d occurrences", term, ile.WordOccurrences[term])
+		}
+	}
+
+	sections, err := MatchingSections(ile, "spec.template.spec.containers[0].image")
+	if err != nil {
+		t.Fatalf("MatchingSections() returned unexpected error: %v", err)
+	}
+
+	expected := []string{"document[1]"}
+	if !reflect.DeepEqual(sections, expected) {
+		t.Errorf("MatchingSections() = %v, want %v", sections, expected)
+	}
+}
+
+func TestIndexEntry_Chunks(t *testing.T) {
+	tempFile, err := os.CreateTemp("", "test*.txt")
+	if err != nil {
+		t.Fatalf("Failed to create temporary file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	content := "Apples grow on trees.\n\nBananas grow in bunches.\n\nCherries are small.\n"
+	if err := os.WriteFile(tempFile.Name(), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write to temp file: %v", err)
+	}
+
+	ile := &pb.IndexListEntry{
+		Name: "fruit",
+		ContentMetadata: &pb.ContentMetadata{
+			URI:      tempFile.Name(),
+			DataType: pb.DataType_TEXT,
+		},
+	}
+
+	if err := IndexEntry(ile, &pb.ChunkingConfig{Chunker: "paragraph", MaxTokens: 6}); err != nil {
+		t.Fatalf("IndexEntry() returned unexpected error: %v", err)
+	}
+
+	if len(ile.Chunks) != 3 {
+		t.Fatalf("Expected 3 chunks, got %d", len(ile.Chunks))
+	}
+
+	chunks, err := MatchingChunks(ile, "bunch")
+	if err != nil {
+		t.Fatalf("MatchingChunks() returned unexpected error: %v", err)
+	}
+
+	if len(chunks) != 1 || chunks[0].Ordinal != 1 || chunks[0].EntryName != "fruit" || chunks[0].StartLine != 3 {
+		t.Errorf("Expected the second chunk to match, got %v", chunks)
+	}
+}
+
+func TestScoreText(t *testing.T) {
+	tests := []struct {
+		text     string
+		query    string
+		expected int32
+	}{
+		{"the users table stores users", "users", 4},
+		{"each user has a row", "users table", 1},
+		{"set server.port in the config", "server.port", 5},
+		{"nothing related", "users", 0},
+	}
+
+	for _, tt := range tests {
+		score, err := ScoreText(tt.text, tt.query)
+		if err != nil {
+			t.Fatalf("ScoreText() returned unexpected error: %v", err)
+		}
+		if score != tt.expected {
+			t.Errorf("ScoreText(%q, %q) = %d, expected %d", tt.text, tt.query, score, tt.expected)
+		}
+	}
+}
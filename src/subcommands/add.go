@@ -8,8 +8,10 @@ import (
 	"path"
 
 	db "accretional.com/semantifly/database"
+	fetch "accretional.com/semantifly/fetcher"
 	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
 	search "accretional.com/semantifly/search"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -24,35 +26,163 @@ func SubcommandAdd(ctx context.Context, conn *db.PgxIface, a *pb.AddRequest, ind
 		return fmt.Errorf("failed to read the index file: %v", err)
 	}
 
+	config, err := readConfig(indexPath)
+	if err != nil {
+		return fmt.Errorf("failed to read the config file: %v", err)
+	}
+
+	if a.AddedMetadata.DataType == pb.DataType_CUSTOM {
+		if err := loadTypes(indexPath); err != nil {
+			return fmt.Errorf("failed to load types: %v", err)
+		}
+
+		definition, err := findType(indexPath, a.AddedMetadata.TypeName)
+		if err != nil {
+			return fmt.Errorf("failed to read types: %v", err)
+		}
+		if definition == nil {
+			return invalidArgumentf("unknown data type: %s. Add it with 'add type' first", a.AddedMetadata.TypeName)
+		}
+	}
+
+	if a.AddedMetadata.SourceType == pb.SourceType_LOCAL_FILE && fetch.IsArchive(a.AddedMetadata.URI) {
+		return addArchive(ctx, conn, a, indexPath, indexMap, config, w)
+	}
+
 	if indexMap[a.AddedMetadata.URI] != nil {
 		return fmt.Errorf("file %s has already been added. Skipping without refresh", a.AddedMetadata.URI)
 	}
 
+	if a.DetectDataType {
+		if err := detectDataType(a.AddedMetadata, config); err != nil {
+			return err
+		}
+	}
+
+	if documents := yamlDocuments(a.AddedMetadata); documents != nil {
+		added := newEntries(documents, false, a.MakeCopy, indexPath, indexMap, config, w)
+		return writeAddedEntries(ctx, conn, indexFilePath, indexMap, added)
+	}
+
+	ile := newIndexListEntry(a.AddedMetadata, a.MakeCopy, indexPath, config, w)
+	indexMap[ile.Name] = ile
+
+	return writeAddedEntries(ctx, conn, indexFilePath, indexMap, []*pb.IndexListEntry{ile})
+}
+
+// addArchive adds each file within a local archive as its own entry, named "archive.zip!/path/in/archive".
+// When detecting data types, each member is detected on its own.
+func addArchive(ctx context.Context, conn *db.PgxIface, a *pb.AddRequest, indexPath string, indexMap map[string]*pb.IndexListEntry, config *pb.Config, w io.Writer) error {
+	members, err := fetch.ListArchiveMembers(a.AddedMetadata.URI)
+	if err != nil {
+		return fmt.Errorf("failed to list members of archive %s: %v", a.AddedMetadata.URI, err)
+	}
+
+	var sources []*pb.ContentMetadata
+	for _, member := range members {
+		sources = append(sources, &pb.ContentMetadata{
+			URI:            fetch.ArchiveMemberURI(a.AddedMetadata.URI, member),
+			DataType:       a.AddedMetadata.DataType,
+			SourceType:     pb.SourceType_ARCHIVE,
+			ExtractOptions: a.AddedMetadata.ExtractOptions,
+			TypeName:       a.AddedMetadata.TypeName,
+		})
+	}
+
+	added := newEntries(sources, a.DetectDataType, a.MakeCopy, indexPath, indexMap, config, w)
+	return writeAddedEntries(ctx, conn, path.Join(indexPath, indexFile), indexMap, added)
+}
+
+// newEntries creates the index entries of several sources, such as the members of an archive, skipping those that
+// have already been added. YAML streams of several documents are added as one entry per document.
+func newEntries(sources []*pb.ContentMetadata, detect bool, makeLocalCopy bool, indexPath string, indexMap map[string]*pb.IndexListEntry, config *pb.Config, w io.Writer) []*pb.IndexListEntry {
+	var added []*pb.IndexListEntry
+	for _, metadata := range sources {
+		if indexMap[metadata.URI] != nil {
+			fmt.Fprintf(w, "File %s has already been added. Skipping without refresh.\n", metadata.URI)
+			continue
+		}
+
+		if detect {
+			if err := detectDataType(metadata, config); err != nil {
+				fmt.Fprintf(w, "%v. Skipping.\n", err)
+				continue
+			}
+		}
+
+		if documents := yamlDocuments(metadata); documents != nil {
+			added = append(added, newEntries(documents, false, makeLocalCopy, indexPath, indexMap, config, w)...)
+			continue
+		}
+
+		ile := newIndexListEntry(metadata, makeLocalCopy, indexPath, config, w)
+		indexMap[ile.Name] = ile
+		added = append(added, ile)
+	}
+
+	return added
+}
+
+// yamlDocuments returns the metadata of each document of a YAML stream holding several, named like
+// "app.yaml#document[1]", or nil if the content is not YAML or holds a single document.
+// Failures to fetch the content are left for indexing the stream as a whole to report.
+func yamlDocuments(metadata *pb.ContentMetadata) []*pb.ContentMetadata {
+	if metadata.DataType != pb.DataType_YAML {
+		return nil
+	}
+
+	content, err := fetch.FetchFromSource(metadata.SourceType, metadata.URI)
+	if err != nil {
+		return nil
+	}
+
+	count := len(fetch.SplitYAMLDocuments(content))
+	if count <= 1 {
+		return nil
+	}
+
+	documents := make([]*pb.ContentMetadata, count)
+	for i := range documents {
+		documents[i] = proto.Clone(metadata).(*pb.ContentMetadata)
+		documents[i].URI = fetch.YAMLDocumentURI(metadata.URI, i)
+	}
+	return documents
+}
+
+// newIndexListEntry creates the index entry for newly added content, making a copy of it if requested,
+// and chunks it according to the config.
+// Failures to copy or index the content are reported to w rather than failing the add.
+func newIndexListEntry(metadata *pb.ContentMetadata, makeLocalCopy bool, indexPath string, config *pb.Config, w io.Writer) *pb.IndexListEntry {
 	ile := &pb.IndexListEntry{
-		Name:            a.AddedMetadata.URI,
-		ContentMetadata: a.AddedMetadata,
+		Name:            metadata.URI,
+		ContentMetadata: metadata,
 		FirstAddedTime:  timestamppb.Now(),
 	}
 
-	if a.MakeCopy {
-		err = makeCopy(indexPath, ile)
-		if err != nil {
-			fmt.Fprintf(w, "Failed to make a copy for %s: %v. Skipping.\n", a.AddedMetadata.URI, err)
+	if makeLocalCopy {
+		if err := makeCopy(indexPath, ile); err != nil {
+			fmt.Fprintf(w, "Failed to make a copy for %s: %v. Skipping.\n", metadata.URI, err)
 		}
 	}
 
-	err = search.CreateSearchDictionary(ile)
-	if err != nil {
-		fmt.Fprintf(w, "File %s failed to create search dictionary with err: %s. Skipping.\n", a, err)
+	if err := search.IndexEntry(ile, chunkingConfig(config, metadata)); err != nil {
+		fmt.Fprintf(w, "File %s failed to create search dictionary with err: %s. Skipping.\n", metadata.URI, err)
 	}
 
-	indexMap[ile.Name] = ile
+	return ile
+}
 
+func writeAddedEntries(ctx context.Context, conn *db.PgxIface, indexFilePath string, indexMap map[string]*pb.IndexListEntry, added []*pb.IndexListEntry) error {
 	if err := writeIndex(indexFilePath, indexMap); err != nil {
 		return fmt.Errorf("failed to write to the index file: %v", err)
 	}
 
-	if err := db.InsertRows(ctx, conn, &pb.Index{Entries: []*pb.IndexListEntry{ile}}); err != nil {
+	// nothing is written for archives whose members have all been added already
+	if len(added) == 0 {
+		return nil
+	}
+
+	if err := db.InsertRows(ctx, conn, &pb.Index{Entries: added}); err != nil {
 		return fmt.Errorf("failed to write to the database: %v", err)
 	}
 
@@ -1,6 +1,7 @@
 package subcommands
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
 	"fmt"
@@ -406,3 +407,193 @@ func TestAdd_Database(t *testing.T) {
 		t.Errorf("FirstAddedTime is nil")
 	}
 }
+
+// writeTestZip writes a zip archive holding the given files, by path within the archive.
+func writeTestZip(t *testing.T, archivePath string, files map[string]string) {
+	f, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to add %s to archive: %v", name, err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("Failed to write %s to archive: %v", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Failed to close archive: %v", err)
+	}
+}
+
+func TestAdd_Archive(t *testing.T) {
+	tempDir := t.TempDir()
+	archivePath := path.Join(tempDir, "sdk.zip")
+	writeTestZip(t, archivePath, map[string]string{"README.md": "# SDK", "docs/guide.txt": "guide content"})
+
+	ctx, conn, err := setupDatabaseForTesting()
+	if err != nil {
+		t.Fatalf("Failed to setup the testing database: %v", err)
+	}
+	defer conn.Close(ctx)
+
+	var dbConn database.PgxIface = conn
+
+	args := &pb.AddRequest{
+		AddedMetadata: &pb.ContentMetadata{URI: archivePath},
+	}
+
+	var buf bytes.Buffer
+	if err := SubcommandAdd(ctx, &dbConn, args, tempDir, &buf); err != nil {
+		t.Fatalf("Add function returned an error: %v", err)
+	}
+
+	indexMap, err := readIndex(path.Join(tempDir, indexFile), false)
+	if err != nil {
+		t.Fatalf("Failed to read index file: %v", err)
+	}
+	for _, member := range []string{"README.md", "docs/guide.txt"} {
+		name := archivePath + "!/" + member
+		entry, exists := indexMap[name]
+		if !exists {
+			t.Errorf("Member %s was not added to the index", name)
+			continue
+		}
+		if entry.ContentMetadata.SourceType != pb.SourceType_ARCHIVE {
+			t.Errorf("Expected SourceType %v for %s, got %v", pb.SourceType_ARCHIVE, name, entry.ContentMetadata.SourceType)
+		}
+	}
+
+	// adding the archive again adds nothing, and must not fail writing an empty batch to the database
+	buf.Reset()
+	if err := SubcommandAdd(ctx, &dbConn, args, tempDir, &buf); err != nil {
+		t.Fatalf("Adding the archive again returned an error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "has already been added") {
+		t.Errorf("Expected the members to be skipped, got:\n%s", buf.String())
+	}
+}
+
+func TestAdd_EmptyArchive(t *testing.T) {
+	tempDir := t.TempDir()
+	archivePath := path.Join(tempDir, "empty.zip")
+	writeTestZip(t, archivePath, nil)
+
+	args := &pb.AddRequest{
+		AddedMetadata: &pb.ContentMetadata{URI: archivePath},
+	}
+
+	// no connection, as nothing is written to the database
+	var buf bytes.Buffer
+	if err := SubcommandAdd(context.Background(), nil, args, tempDir, &buf); err != nil {
+		t.Fatalf("Add function returned an error for an empty archive: %v", err)
+	}
+
+	indexMap, err := readIndex(path.Join(tempDir, indexFile), true)
+	if err != nil {
+		t.Fatalf("Failed to read index file: %v", err)
+	}
+	if len(indexMap) != 0 {
+		t.Errorf("Expected an empty index, got %v", indexMap)
+	}
+}
+
+func TestAdd_YAMLDocuments(t *testing.T) {
+	tempDir := t.TempDir()
+	streamPath := path.Join(tempDir, "app.yaml")
+	stream := "kind: Service\n---\nkind: Deployment\nspec:\n  replicas: 2\n"
+	if err := os.WriteFile(streamPath, []byte(stream), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	ctx, conn, err := setupDatabaseForTesting()
+	if err != nil {
+		t.Fatalf("Failed to setup the testing database: %v", err)
+	}
+	defer conn.Close(ctx)
+
+	var dbConn database.PgxIface = conn
+
+	args := &pb.AddRequest{
+		AddedMetadata:  &pb.ContentMetadata{URI: streamPath},
+		DetectDataType: true,
+	}
+
+	var buf bytes.Buffer
+	if err := SubcommandAdd(ctx, &dbConn, args, tempDir, &buf); err != nil {
+		t.Fatalf("Add function returned an error: %v", err)
+	}
+
+	indexMap, err := readIndex(path.Join(tempDir, indexFile), false)
+	if err != nil {
+		t.Fatalf("Failed to read index file: %v", err)
+	}
+	if _, exists := indexMap[streamPath]; exists || len(indexMap) != 2 {
+		t.Fatalf("Expected an entry per document, got %v", indexMap)
+	}
+
+	deployment := indexMap[streamPath+"#document[1]"]
+	if deployment == nil || deployment.ContentMetadata.DataType != pb.DataType_YAML || deployment.WordOccurrences["spec.replicas"] != 1 {
+		t.Errorf("Unexpected entry of the second document: %v", deployment)
+	}
+	if deployment != nil && deployment.WordOccurrences["Service"] != 0 {
+		t.Errorf("Expected the entry of the second document to only index it, got %v", deployment.WordOccurrences)
+	}
+}
+
+func TestDetectDataType_Config(t *testing.T) {
+	indexPath := t.TempDir()
+
+	config := `{"dataTypes": {".conf": "YAML", "Pipfile": "TOML"}}`
+	if err := os.WriteFile(path.Join(indexPath, configFile), []byte(config), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"app.conf": "key: value\n",
+		"Pipfile":  "[packages]\n",
+		"notes.md": "# Notes\n",
+		"data":     `{"nbformat": 4, "cells": []}`,
+	}
+	expected := map[string]struct {
+		dataType   pb.DataType
+		detectedBy string
+	}{
+		"app.conf": {pb.DataType_YAML, "config .conf"},
+		"Pipfile":  {pb.DataType_TOML, "config Pipfile"},
+		"notes.md": {pb.DataType_MARKDOWN, "extension .md"},
+		"data":     {pb.DataType_IPYNB, "content"},
+	}
+
+	cfg, err := readConfig(indexPath)
+	if err != nil {
+		t.Fatalf("readConfig() returned unexpected error: %v", err)
+	}
+
+	for name, content := range files {
+		uri := path.Join(dir, name)
+		if err := os.WriteFile(uri, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+
+		metadata := &pb.ContentMetadata{URI: uri, SourceType: pb.SourceType_LOCAL_FILE}
+		if err := detectDataType(metadata, cfg); err != nil {
+			t.Fatalf("detectDataType() returned unexpected error for %s: %v", name, err)
+		}
+
+		want := expected[name]
+		if metadata.DataType != want.dataType || metadata.DetectedBy != want.detectedBy {
+			t.Errorf("Expected %s to be detected as %s by %q, got %s by %q", name, want.dataType, want.detectedBy, metadata.DataType, metadata.DetectedBy)
+		}
+	}
+
+	if _, err := readConfig(t.TempDir()); err != nil {
+		t.Errorf("Expected a missing config file to be ignored, got: %v", err)
+	}
+}
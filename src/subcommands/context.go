@@ -0,0 +1,186 @@
+package subcommands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	db "accretional.com/semantifly/database"
+	"accretional.com/semantifly/prompt"
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+	"accretional.com/semantifly/tokens"
+)
+
+const (
+	defaultContextTokens  = 2000
+	defaultContextRecords = 20
+
+	// minTruncatedTokens is the least text worth including of a chunk that does not fit the budget
+	minTruncatedTokens = 32
+)
+
+// SubcommandContext retrieves the chunks most related to a question and packs them into a context block of at
+// most max_tokens tokens, most relevant first. A chunk that does not fit is truncated if enough of the budget is
+// left, and skipped otherwise so that smaller chunks may still fit. The chunks are then grouped by entry in the
+// order of their most relevant chunk, and by position within each entry, each rendered by the chunk template of the
+// request's prompt template, by default its citation followed by its text.
+func SubcommandContext(ctx context.Context, conn *db.PgxIface, c *pb.ContextRequest, indexPath string, w io.Writer) (*pb.ContextResponse, error) {
+	if strings.TrimSpace(c.Question) == "" {
+		return nil, invalidArgumentf("question is empty")
+	}
+
+	maxTokens := int(c.MaxTokens)
+	if maxTokens <= 0 {
+		maxTokens = defaultContextTokens
+	}
+
+	counter, err := tokens.New(c.Tokenizer)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := loadTemplate(indexPath, c.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	search := &pb.SemanticSearchRequest{}
+	if c.Search != nil {
+		search = proto.Clone(c.Search).(*pb.SemanticSearchRequest)
+	}
+	search.Query = c.Question
+	if search.MaxRecords <= 0 {
+		search.MaxRecords = defaultContextRecords
+	}
+
+	found, err := SubcommandSemanticSearch(ctx, conn, search, indexPath, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve chunks: %w", err)
+	}
+
+	// chunk templates are chosen by the type of the entries, which the records do not hold
+	indexMap, err := readIndex(path.Join(indexPath, indexFile), true)
+	if err != nil {
+		return nil, err
+	}
+	dataTypes := make(map[string]string)
+	for _, record := range found.Records {
+		if entry, ok := indexMap[record.Name]; ok {
+			dataTypes[record.Name] = typeName(entry.ContentMetadata)
+		}
+	}
+
+	packed, err := packRecords(counter, t, dataTypes, found.Records, maxTokens)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ContextResponse{}
+	var sb strings.Builder
+	for i, record := range packed {
+		citation := recordCitation(record, dataTypes)
+		citation.Number = int32(i + 1)
+		resp.Citations = append(resp.Citations, citation)
+
+		block, err := t.Block(citation, record.Chunk.GetText())
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(block)
+	}
+
+	resp.Context = sb.String()
+	resp.TokenCount = int32(counter.Count(resp.Context))
+	return resp, nil
+}
+
+// packRecords picks records by relevance while their blocks fit in maxTokens, truncating the first that does not
+// fit if at least minTruncatedTokens of its text can be kept, and returns them in the order of the context.
+// Returned records are copies; truncated chunks have Trimmed set, and their text and line range cut short.
+func packRecords(counter tokens.Counter, t *prompt.Template, dataTypes map[string]string, records []*pb.SearchRecord, maxTokens int) ([]*pb.SearchRecord, error) {
+	remaining := maxTokens
+	rank := make(map[string]int)
+	var packed []*pb.SearchRecord
+
+	for _, record := range records {
+		// citations are numbered once packed, and numbers up to 99 take at most as many tokens as 99; records
+		// trimmed by the byte budget of the search are already truncated
+		citation := recordCitation(record, dataTypes)
+		citation.Number = 99
+		separator := 0
+		if len(packed) > 0 {
+			separator = counter.Count("\n")
+		}
+
+		text := record.Chunk.GetText()
+		block, err := t.Block(citation, text)
+		if err != nil {
+			return nil, err
+		}
+		if cost := separator + counter.Count(block); cost > remaining {
+			// the block without text, counted apart from the line break ending the text and the marker
+			empty := proto.Clone(citation).(*pb.Citation)
+			empty.Truncated = false
+			frame, err := t.Block(empty, "")
+			if err != nil {
+				return nil, err
+			}
+			overhead := separator + counter.Count(frame) + counter.Count("\n") + counter.Count(prompt.TruncationMarker)
+			if remaining-overhead < minTruncatedTokens {
+				continue
+			}
+			text = tokens.Truncate(counter, text, remaining-overhead)
+			citation.Truncated = true
+			if block, err = t.Block(citation, text); err != nil {
+				return nil, err
+			}
+		}
+
+		trimmed := citation.Truncated
+		remaining -= separator + counter.Count(block)
+
+		chunk := proto.Clone(record.Chunk).(*pb.Chunk)
+		chunk.Text = text
+		chunk.Embedding = nil
+		if trimmed && chunk.StartLine > 0 {
+			chunk.EndLine = chunk.StartLine + int32(strings.Count(strings.TrimSuffix(text, "\n"), "\n"))
+		}
+		packed = append(packed, &pb.SearchRecord{Name: record.Name, Chunk: chunk, Score: record.Score, Trimmed: trimmed})
+		if _, ok := rank[record.Name]; !ok {
+			rank[record.Name] = len(rank)
+		}
+
+		if trimmed {
+			break
+		}
+	}
+
+	sort.SliceStable(packed, func(i, j int) bool {
+		if packed[i].Name != packed[j].Name {
+			return rank[packed[i].Name] < rank[packed[j].Name]
+		}
+		return packed[i].Chunk.GetOrdinal() < packed[j].Chunk.GetOrdinal()
+	})
+
+	return packed, nil
+}
+
+// recordCitation returns the citation of a record, unnumbered.
+func recordCitation(record *pb.SearchRecord, dataTypes map[string]string) *pb.Citation {
+	return &pb.Citation{
+		Name:      record.Name,
+		StartLine: record.Chunk.GetStartLine(),
+		EndLine:   record.Chunk.GetEndLine(),
+		Path:      record.Chunk.GetPath(),
+		Truncated: record.Trimmed,
+		DataType:  dataTypes[record.Name],
+	}
+}
@@ -0,0 +1,99 @@
+package subcommands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"testing"
+
+	"accretional.com/semantifly/prompt"
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+	"accretional.com/semantifly/tokens"
+)
+
+func TestSubcommandContext(t *testing.T) {
+	indexPath := t.TempDir()
+
+	var guide []string
+	for i := 4; i <= 100; i++ {
+		guide = append(guide, fmt.Sprintf("line %d of the guide", i))
+	}
+	guide[0] += " about users"
+
+	indexMap := map[string]*pb.IndexListEntry{
+		"guide.md": {Name: "guide.md", Chunks: []*pb.Chunk{
+			{EntryName: "guide.md", Ordinal: 0, StartLine: 1, EndLine: 3, Text: "Users are stored in the users table."},
+			{EntryName: "guide.md", Ordinal: 1, StartLine: 4, EndLine: 100, Text: strings.Join(guide, "\n"), Path: "Storage"},
+		}},
+		"faq.md": {Name: "faq.md", Chunks: []*pb.Chunk{
+			{EntryName: "faq.md", Ordinal: 0, StartLine: 1, EndLine: 2, Text: "How to add users? Insert users, users and users."},
+		}},
+	}
+	if err := writeIndex(path.Join(indexPath, indexFile), indexMap); err != nil {
+		t.Fatalf("Failed to write index: %v", err)
+	}
+
+	request := &pb.ContextRequest{
+		Question:  "users",
+		Tokenizer: "words",
+		Search:    &pb.SemanticSearchRequest{Mode: pb.SearchMode_LEXICAL},
+	}
+
+	var buf bytes.Buffer
+	resp, err := SubcommandContext(context.Background(), nil, request, indexPath, &buf)
+	if err != nil {
+		t.Fatalf("SubcommandContext() returned unexpected error: %v", err)
+	}
+
+	// the most relevant entry comes first, and chunks of an entry are in order
+	var cited []string
+	for _, citation := range resp.Citations {
+		cited = append(cited, fmt.Sprintf("[%d] %s:%d-%d", citation.Number, citation.Name, citation.StartLine, citation.EndLine))
+	}
+	if got := strings.Join(cited, ", "); got != "[1] faq.md:1-2, [2] guide.md:1-3, [3] guide.md:4-100" {
+		t.Errorf("Unexpected citations: %s", got)
+	}
+	if !strings.HasPrefix(resp.Context, "[1] faq.md, lines 1-2\nHow to add users?") || !strings.Contains(resp.Context, "\n[3] guide.md, lines 4-100 (Storage)\nline 4") {
+		t.Errorf("Unexpected context:\n%s", resp.Context)
+	}
+
+	// the chunk that does not fit the budget is truncated
+	request.MaxTokens = 100
+	resp, err = SubcommandContext(context.Background(), nil, request, indexPath, &buf)
+	if err != nil {
+		t.Fatalf("SubcommandContext() returned unexpected error: %v", err)
+	}
+	counter, _ := tokens.New("words")
+	if resp.TokenCount > 100 || int(resp.TokenCount) != counter.Count(resp.Context) {
+		t.Errorf("Expected at most 100 tokens, got %d", resp.TokenCount)
+	}
+	if len(resp.Citations) != 3 || !resp.Citations[2].Truncated || resp.Citations[2].EndLine >= 100 {
+		t.Fatalf("Expected the last chunk to be truncated, got %v", resp.Citations)
+	}
+	if !strings.HasSuffix(resp.Context, fmt.Sprintf("line %d of the guide\n%s", resp.Citations[2].EndLine, prompt.TruncationMarker)) {
+		t.Errorf("Expected the context to end with the last line kept and a marker, got:\n%s", resp.Context)
+	}
+
+	if _, err := SubcommandContext(context.Background(), nil, &pb.ContextRequest{Question: "users", Tokenizer: "unknown"}, indexPath, &buf); err == nil {
+		t.Error("Expected an error for an unknown tokenizer, but got nil")
+	}
+}
+
+func TestPackRecords_SkipsChunksThatDoNotFit(t *testing.T) {
+	counter, _ := tokens.New("words")
+	records := []*pb.SearchRecord{
+		{Name: "big", Chunk: &pb.Chunk{Text: strings.Repeat("word ", 100)}},
+		{Name: "small", Chunk: &pb.Chunk{Text: "a few words"}},
+	}
+
+	defaultTemplate, _ := prompt.Parse(prompt.Builtin(prompt.DefaultTemplate))
+	packed, err := packRecords(counter, defaultTemplate, nil, records, 20)
+	if err != nil {
+		t.Fatalf("packRecords() returned unexpected error: %v", err)
+	}
+	if len(packed) != 1 || packed[0].Name != "small" || packed[0].Trimmed {
+		t.Errorf("Expected only the small chunk to be packed, got %v", packed)
+	}
+}
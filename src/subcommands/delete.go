@@ -6,8 +6,11 @@ import (
 	"io"
 	"os"
 	"path"
+	"sort"
+	"strings"
 
 	db "accretional.com/semantifly/database"
+	fetch "accretional.com/semantifly/fetcher"
 	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
 )
 
@@ -20,7 +23,9 @@ func SubcommandDelete(ctx context.Context, conn *db.PgxIface, d *pb.DeleteReques
 		return fmt.Errorf("failed to read the index file: %v", err)
 	}
 
-	for _, uri := range d.Names {
+	names := expandSourceNames(indexMap, d.Names)
+
+	for _, uri := range names {
 
 		if _, present := indexMap[uri]; !present {
 			fmt.Fprintf(w, "Entry %s not found in index file %s, skipping\n", uri, indexFilePath)
@@ -40,13 +45,47 @@ func SubcommandDelete(ctx context.Context, conn *db.PgxIface, d *pb.DeleteReques
 		return fmt.Errorf("failed to write to the index file: %v", err)
 	}
 
-	if err := db.DeleteRows(ctx, conn, d.Names); err != nil {
+	if err := db.DeleteRows(ctx, conn, names); err != nil {
 		return fmt.Errorf("failed to delete from the database: %v", err)
 	}
 
 	return nil
 }
 
+// expandSourceNames replaces the names of archives and YAML streams that are not entries themselves with the
+// names of their members or documents in the index, so that deleting an archive deletes all of its members.
+func expandSourceNames(indexMap map[string]*pb.IndexListEntry, names []string) []string {
+	var expanded []string
+	for _, name := range names {
+		if _, present := indexMap[name]; present {
+			expanded = append(expanded, name)
+			continue
+		}
+
+		prefix := name + fetch.DocumentSeparator
+		if fetch.IsArchive(name) {
+			prefix = name + fetch.ArchiveSeparator
+		}
+
+		var members []string
+		for entryName := range indexMap {
+			if strings.HasPrefix(entryName, prefix) {
+				members = append(members, entryName)
+			}
+		}
+
+		if len(members) == 0 {
+			expanded = append(expanded, name)
+			continue
+		}
+
+		sort.Strings(members)
+		expanded = append(expanded, members...)
+	}
+
+	return expanded
+}
+
 // deleteCopy deletes a copied file with the given name from the specified index path.
 //
 // Parameters:
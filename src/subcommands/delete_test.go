@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"reflect"
 	"testing"
 
 	"accretional.com/semantifly/database"
@@ -108,3 +109,24 @@ func TestDelete(t *testing.T) {
 		t.Errorf("Data file for %s was not deleted", testFilePath1)
 	}
 }
+
+func TestExpandSourceNames(t *testing.T) {
+	indexMap := map[string]*pb.IndexListEntry{
+		"/sdk/bundle.zip!/docs/b.md":   {Name: "/sdk/bundle.zip!/docs/b.md"},
+		"/sdk/bundle.zip!/a.md":        {Name: "/sdk/bundle.zip!/a.md"},
+		"/sdk/other.zip!/a.md":         {Name: "/sdk/other.zip!/a.md"},
+		"/docs/notes.txt":              {Name: "/docs/notes.txt"},
+		"/deploy/app.yaml#document[1]": {Name: "/deploy/app.yaml#document[1]"},
+		"/deploy/app.yaml#document[0]": {Name: "/deploy/app.yaml#document[0]"},
+	}
+
+	names := expandSourceNames(indexMap, []string{"/sdk/bundle.zip", "/docs/notes.txt", "/sdk/missing.zip", "/deploy/app.yaml"})
+
+	expected := []string{
+		"/sdk/bundle.zip!/a.md", "/sdk/bundle.zip!/docs/b.md", "/docs/notes.txt", "/sdk/missing.zip",
+		"/deploy/app.yaml#document[0]", "/deploy/app.yaml#document[1]",
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected names %v, got %v", expected, names)
+	}
+}
@@ -0,0 +1,256 @@
+package subcommands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"accretional.com/semantifly/chunker"
+	"accretional.com/semantifly/embedder"
+	extract "accretional.com/semantifly/extractor"
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+const defaultTopTerms = 10
+
+// SubcommandDescribeData returns everything stored about an entry of the index: its metadata, timestamps,
+// size and digest of its content, whether a copy was made, and its most frequent terms.
+func SubcommandDescribeData(d *pb.DescribeDataRequest, indexPath string, w io.Writer) (*pb.DataDescription, error) {
+	indexFilePath := path.Join(indexPath, indexFile)
+
+	indexMap, err := readIndex(indexFilePath, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the index file: %v", err)
+	}
+
+	entry := indexMap[d.Name]
+	if entry == nil {
+		return nil, notFoundf("entry '%s' not found in index file %s", d.Name, indexFilePath)
+	}
+
+	description := &pb.DataDescription{
+		Name:              entry.Name,
+		ContentMetadata:   entry.ContentMetadata,
+		FirstAddedTime:    entry.FirstAddedTime,
+		LastRefreshedTime: entry.LastRefreshedTime,
+		Size:              entry.Size,
+		Digest:            entry.Digest,
+		SectionCount:      int32(len(entry.Sections)),
+		DistinctTerms:     int32(len(entry.WordOccurrences)),
+		ChunkCount:        int32(len(entry.Chunks)),
+	}
+
+	copyPath := path.Join(indexPath, addedCopiesSubDir, entry.Name)
+	if info, err := os.Stat(copyPath); err == nil {
+		description.HasCopy = true
+		description.CopyPath = copyPath
+		description.CopySize = info.Size()
+	} else if !os.IsNotExist(err) {
+		fmt.Fprintf(w, "failed to stat copy of %s: %v\n", entry.Name, err)
+	}
+
+	topTerms := int(d.TopTerms)
+	if topTerms <= 0 {
+		topTerms = defaultTopTerms
+	}
+	description.TopTerms = mostFrequentTerms(entry.WordOccurrences, topTerms)
+
+	return description, nil
+}
+
+// SubcommandDescribeType returns what is known about a built-in DataType or a user defined type:
+// what its extractor produces, which extensions are detected as it, and how many entries have it.
+func SubcommandDescribeType(d *pb.DescribeTypeRequest, indexPath string, w io.Writer) (*pb.TypeDescription, error) {
+	description := &pb.TypeDescription{}
+
+	dataType := pb.DataType_CUSTOM
+	if val, ok := pb.DataType_value[strings.ToUpper(d.Name)]; ok && pb.DataType(val) != pb.DataType_CUSTOM {
+		dataType = pb.DataType(val)
+		description.Name = dataType.String()
+		description.BuiltIn = true
+		description.Extensions = extract.Extensions(dataType)
+	} else {
+		definition, err := findType(indexPath, d.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read types: %v", err)
+		}
+		if definition == nil {
+			return nil, notFoundf("unknown data type: %s", d.Name)
+		}
+		description.Name = definition.Name
+		description.MessageName = definition.MessageName
+		description.ProtoFile = definition.ProtoFile
+		description.FirstAddedTime = definition.FirstAddedTime
+	}
+	description.Extractor = extract.Description(dataType)
+
+	config, err := readConfig(indexPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the config file: %v", err)
+	}
+	chunking := chunkingConfig(config, &pb.ContentMetadata{DataType: dataType, TypeName: description.Name})
+	description.Chunking = chunker.Resolve(chunking, "", extract.HasSections(dataType))
+	// the chunker of entries without sections depends on whether their file is source code, eg. main.go
+	if codeChunking := chunker.Resolve(chunking, "main.go", extract.HasSections(dataType)); codeChunking.Chunker != description.Chunking.Chunker {
+		description.CodeChunking = codeChunking
+	}
+	description.Embedding = embeddingSettings(config.GetEmbedding())
+
+	indexMap, err := readIndex(path.Join(indexPath, indexFile), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the index file: %v", err)
+	}
+
+	for _, entry := range indexMap {
+		metadata := entry.GetContentMetadata()
+		if metadata.GetDataType() == dataType && (description.BuiltIn || metadata.GetTypeName() == description.Name) {
+			description.EntryCount++
+		}
+	}
+
+	return description, nil
+}
+
+// embeddingSettings returns the provider, model and dimensions of the embeddings computed with config, with the
+// defaults of the provider filled in. The dimensions of models that choose them are only known once used.
+func embeddingSettings(config *pb.EmbeddingConfig) *pb.EmbeddingConfig {
+	settings := &pb.EmbeddingConfig{
+		Provider:   config.GetProvider(),
+		Model:      config.GetModel(),
+		Dimensions: config.GetDimensions(),
+	}
+	if settings.Provider == "" {
+		settings.Provider = "hash"
+	}
+
+	if model, err := embedder.New(config); err == nil {
+		settings.Model = model.Model()
+		if dimension := model.Dimension(); dimension > 0 {
+			settings.Dimensions = int32(dimension)
+		}
+	}
+
+	return settings
+}
+
+// mostFrequentTerms returns the n terms with the most occurrences, most frequent first.
+func mostFrequentTerms(occurrences map[string]int32, n int) []*pb.TermCount {
+	terms := make([]*pb.TermCount, 0, len(occurrences))
+	for term, count := range occurrences {
+		terms = append(terms, &pb.TermCount{Term: term, Count: count})
+	}
+
+	sort.Slice(terms, func(i, j int) bool {
+		if terms[i].Count != terms[j].Count {
+			return terms[i].Count > terms[j].Count
+		}
+		return terms[i].Term < terms[j].Term
+	})
+
+	if len(terms) > n {
+		terms = terms[:n]
+	}
+	return terms
+}
+
+func printDataDescription(w io.Writer, d *pb.DataDescription) {
+	metadata := d.GetContentMetadata()
+
+	dataType := metadata.GetDataType().String()
+	if metadata.GetDataType() == pb.DataType_CUSTOM {
+		dataType = metadata.GetTypeName()
+	}
+	if metadata.GetDetectedBy() != "" {
+		dataType += fmt.Sprintf(" (detected by %s)", metadata.GetDetectedBy())
+	}
+
+	copyStatus := "no"
+	if d.HasCopy {
+		copyStatus = fmt.Sprintf("yes, %d bytes at %s", d.CopySize, d.CopyPath)
+	}
+
+	terms := make([]string, 0, len(d.TopTerms))
+	for _, t := range d.TopTerms {
+		terms = append(terms, fmt.Sprintf("%s (%d)", t.Term, t.Count))
+	}
+
+	printField(w, "Name", d.Name)
+	printField(w, "URI", metadata.GetURI())
+	printField(w, "Data type", dataType)
+	printField(w, "Source type", metadata.GetSourceType().String())
+	printField(w, "Data source", metadata.GetDataSource())
+	printField(w, "Extract options", formatOptions(metadata.GetExtractOptions()))
+	printField(w, "First added", formatTimestamp(d.FirstAddedTime))
+	printField(w, "Last refreshed", formatTimestamp(d.LastRefreshedTime))
+	printField(w, "Size", fmt.Sprintf("%d bytes", d.Size))
+	printField(w, "Digest", d.Digest)
+	printField(w, "Copy", copyStatus)
+	printField(w, "Sections", fmt.Sprint(d.SectionCount))
+	printField(w, "Chunks", fmt.Sprint(d.ChunkCount))
+	printField(w, "Distinct terms", fmt.Sprint(d.DistinctTerms))
+	printField(w, "Top terms", strings.Join(terms, ", "))
+}
+
+func printTypeDescription(w io.Writer, d *pb.TypeDescription) {
+	kind := "user defined"
+	if d.BuiltIn {
+		kind = "built-in"
+	}
+
+	printField(w, "Name", d.Name)
+	printField(w, "Kind", kind)
+	printField(w, "Extractor", d.Extractor)
+	printField(w, "Extensions", strings.Join(d.Extensions, ", "))
+	printField(w, "Message", d.MessageName)
+	printField(w, "Proto file", d.ProtoFile)
+	if d.FirstAddedTime != nil {
+		printField(w, "First added", formatTimestamp(d.FirstAddedTime))
+	}
+	if d.Chunking != nil {
+		printField(w, "Chunking", fmt.Sprintf("%s, up to %d tokens, %d overlapping", d.Chunking.Chunker, d.Chunking.MaxTokens, d.Chunking.OverlapTokens))
+	}
+	if d.CodeChunking != nil {
+		printField(w, "Code chunking", fmt.Sprintf("%s, up to %d tokens, %d overlapping", d.CodeChunking.Chunker, d.CodeChunking.MaxTokens, d.CodeChunking.OverlapTokens))
+	}
+	if d.Embedding != nil {
+		embedding := d.Embedding.Provider
+		if d.Embedding.Model != "" {
+			embedding += ", model " + d.Embedding.Model
+		}
+		if d.Embedding.Dimensions > 0 {
+			embedding += fmt.Sprintf(", %d dimensions", d.Embedding.Dimensions)
+		}
+		printField(w, "Embedding", embedding)
+	}
+	printField(w, "Entries", fmt.Sprint(d.EntryCount))
+}
+
+// printField prints a labeled line of a description, skipping empty values.
+func printField(w io.Writer, label string, value string) {
+	if value == "" {
+		return
+	}
+	fmt.Fprintf(w, "%-16s %s\n", label+":", value)
+}
+
+func formatTimestamp(t *timestamppb.Timestamp) string {
+	if t == nil {
+		return "never"
+	}
+	return t.AsTime().Local().Format(time.RFC3339)
+}
+
+func formatOptions(options map[string]string) string {
+	pairs := make([]string, 0, len(options))
+	for key, value := range options {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,130 @@
+package subcommands
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	"accretional.com/semantifly/chunker"
+	"accretional.com/semantifly/embedder"
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestSubcommandDescribe(t *testing.T) {
+	indexPath := t.TempDir()
+
+	sourceFile := path.Join(t.TempDir(), "notes.md")
+	if err := os.WriteFile(sourceFile, []byte("# Notes\nsearch the index\n"), 0644); err != nil {
+		t.Fatalf("Failed to write source file: %v", err)
+	}
+
+	entry := &pb.IndexListEntry{
+		Name: sourceFile,
+		ContentMetadata: &pb.ContentMetadata{
+			URI:        sourceFile,
+			DataType:   pb.DataType_MARKDOWN,
+			DetectedBy: "extension .md",
+			DataSource: "docs",
+		},
+		FirstAddedTime:  timestamppb.Now(),
+		Size:            25,
+		Digest:          "sha256:abc",
+		Sections:        []*pb.Section{{Path: "Notes"}},
+		WordOccurrences: map[string]int32{"search": 3, "index": 3, "the": 1},
+	}
+	other := &pb.IndexListEntry{
+		Name:            "/docs/other.txt",
+		ContentMetadata: &pb.ContentMetadata{URI: "/docs/other.txt", DataType: pb.DataType_TEXT},
+	}
+
+	indexMap := map[string]*pb.IndexListEntry{entry.Name: entry, other.Name: other}
+	if err := writeIndex(path.Join(indexPath, indexFile), indexMap); err != nil {
+		t.Fatalf("Failed to write index: %v", err)
+	}
+	if err := makeCopy(indexPath, entry); err != nil {
+		t.Fatalf("Failed to make copy: %v", err)
+	}
+
+	var buf bytes.Buffer
+	description, err := SubcommandDescribeData(&pb.DescribeDataRequest{Name: sourceFile, TopTerms: 2}, indexPath, &buf)
+	if err != nil {
+		t.Fatalf("SubcommandDescribeData() returned unexpected error: %v", err)
+	}
+
+	if !description.HasCopy || description.CopySize == 0 {
+		t.Errorf("Expected the copy to be described, got %v", description)
+	}
+	if description.SectionCount != 1 || description.DistinctTerms != 3 || description.Digest != "sha256:abc" {
+		t.Errorf("Unexpected description: %v", description)
+	}
+
+	var terms []string
+	for _, t := range description.TopTerms {
+		terms = append(terms, t.Term)
+	}
+	if !reflect.DeepEqual(terms, []string{"index", "search"}) {
+		t.Errorf("Expected top terms [index search], got %v", terms)
+	}
+
+	buf.Reset()
+	printDataDescription(&buf, description)
+	for _, want := range []string{
+		"Data type:       MARKDOWN (detected by extension .md)",
+		"Data source:     docs",
+		"Copy:            yes",
+		"Top terms:       index (3), search (3)",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, buf.String())
+		}
+	}
+
+	if _, err := SubcommandDescribeData(&pb.DescribeDataRequest{Name: "/missing"}, indexPath, &buf); err == nil {
+		t.Error("Expected an error for a missing entry, but got nil")
+	}
+
+	typeDescription, err := SubcommandDescribeType(&pb.DescribeTypeRequest{Name: "markdown"}, indexPath, &buf)
+	if err != nil {
+		t.Fatalf("SubcommandDescribeType() returned unexpected error: %v", err)
+	}
+	if !typeDescription.BuiltIn || typeDescription.EntryCount != 1 || !reflect.DeepEqual(typeDescription.Extensions, []string{".markdown", ".md"}) {
+		t.Errorf("Unexpected type description: %v", typeDescription)
+	}
+	if typeDescription.Chunking.GetChunker() != "section" || typeDescription.Chunking.GetMaxTokens() != chunker.DefaultMaxTokens {
+		t.Errorf("Expected default section chunking, got %v", typeDescription.Chunking)
+	}
+	if typeDescription.CodeChunking != nil {
+		t.Errorf("Expected no separate chunking of code for a type with sections, got %v", typeDescription.CodeChunking)
+	}
+	if embedding := typeDescription.Embedding; embedding.GetProvider() != "hash" || embedding.GetDimensions() != embedder.DefaultHashDimensions {
+		t.Errorf("Expected the default embedding settings, got %v", embedding)
+	}
+
+	typeDescription, err = SubcommandDescribeType(&pb.DescribeTypeRequest{Name: "text"}, indexPath, &buf)
+	if err != nil {
+		t.Fatalf("SubcommandDescribeType() returned unexpected error: %v", err)
+	}
+	if typeDescription.Chunking.GetChunker() != "paragraph" || typeDescription.CodeChunking.GetChunker() != "symbol" {
+		t.Errorf("Expected paragraph chunking, and symbol chunking of code, got %v and %v", typeDescription.Chunking, typeDescription.CodeChunking)
+	}
+
+	buf.Reset()
+	printTypeDescription(&buf, typeDescription)
+	for _, want := range []string{
+		"Code chunking:   symbol",
+		fmt.Sprintf("Embedding:       hash, model hash-%d, %d dimensions", embedder.DefaultHashDimensions, embedder.DefaultHashDimensions),
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, buf.String())
+		}
+	}
+
+	if _, err := SubcommandDescribeType(&pb.DescribeTypeRequest{Name: "Unknown"}, indexPath, &buf); err == nil {
+		t.Error("Expected an error for an unknown type, but got nil")
+	}
+}
@@ -0,0 +1,269 @@
+package subcommands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"sync"
+
+	db "accretional.com/semantifly/database"
+	"accretional.com/semantifly/embedder"
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+const defaultEmbedWorkers = 4
+
+// SubcommandEmbed computes the vectors of the chunks of the requested entries with the model of the index's config.
+// Chunks whose text and model are unchanged since they were last embedded are skipped unless forced. Chunks are
+// sent to the provider in batches by a bounded pool of workers, and chunks embedded before an error are kept.
+// The vector index is then updated with the vectors of the model. If the database is the requested index source,
+// every selected entry with up to date embeddings is stored in it, including those embedded by earlier runs.
+func SubcommandEmbed(ctx context.Context, conn *db.PgxIface, e *pb.EmbedRequest, indexPath string, w io.Writer) (*pb.EmbedResponse, error) {
+	indexFilePath := path.Join(indexPath, indexFile)
+
+	indexMap, err := readIndex(indexFilePath, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the index file: %v", err)
+	}
+
+	entries, err := entriesToEmbed(indexMap, e)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := readConfig(indexPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the config file: %v", err)
+	}
+
+	model, err := embedder.New(config.GetEmbedding())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the embedder: %v", err)
+	}
+
+	resp := &pb.EmbedResponse{Model: model.Model()}
+
+	var pending []*pb.Chunk
+	for _, entry := range entries {
+		if len(entry.Chunks) == 0 {
+			fmt.Fprintf(w, "Entry %s has no chunks, update it to chunk its content. Skipping.\n", entry.Name)
+			continue
+		}
+		for _, chunk := range entry.Chunks {
+			if !e.Force && embeddingUpToDate(chunk, model.Model()) {
+				resp.UnchangedChunks++
+				continue
+			}
+			pending = append(pending, chunk)
+		}
+	}
+
+	if len(pending) > 0 {
+		fmt.Fprintf(w, "Embedding %d chunks of %d entries with %s\n", len(pending), len(entries), model.Model())
+	}
+
+	batchSize := int(config.GetEmbedding().GetBatchSize())
+	if batchSize <= 0 {
+		batchSize = embedder.DefaultBatchSize
+	}
+	workers := int(e.Workers)
+	if workers <= 0 {
+		workers = defaultEmbedWorkers
+	}
+
+	embedded, embedErr := embedChunks(ctx, model, pending, batchSize, workers, w)
+	resp.EmbeddedChunks = int32(embedded)
+
+	if embedded > 0 {
+		if err := writeIndex(indexFilePath, indexMap); err != nil {
+			return nil, fmt.Errorf("failed to write to the index file: %v", err)
+		}
+	}
+
+	if embedErr != nil {
+		return nil, fmt.Errorf("failed to embed chunks, %d of %d were embedded: %v", embedded, len(pending), embedErr)
+	}
+
+	if err := syncVectorIndex(indexPath, indexMap, model.Model(), config, w); err != nil {
+		return nil, fmt.Errorf("failed to update the vector index: %v", err)
+	}
+
+	// chunks embedded by earlier runs are stored too, so that the database can be filled from the index file
+	if e.IndexSource == pb.IndexSource_DATABASE {
+		if stored := embeddedEntries(entries, model.Model()); len(stored) > 0 {
+			if err := storeEmbeddings(ctx, conn, stored, model.Model(), config); err != nil {
+				return nil, fmt.Errorf("failed to update the database: %v", err)
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "Embedded %d chunks with %s, %d were unchanged\n", resp.EmbeddedChunks, resp.Model, resp.UnchangedChunks)
+	return resp, nil
+}
+
+// entriesToEmbed returns the entries selected by an EmbedRequest, sorted by name.
+func entriesToEmbed(indexMap map[string]*pb.IndexListEntry, e *pb.EmbedRequest) ([]*pb.IndexListEntry, error) {
+	var entries []*pb.IndexListEntry
+
+	switch {
+	case e.All:
+		for _, entry := range indexMap {
+			entries = append(entries, entry)
+		}
+
+	case e.DataType != "":
+		match, err := listFilter(&pb.ListRequest{DataType: e.DataType})
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range indexMap {
+			if match(entry) {
+				entries = append(entries, entry)
+			}
+		}
+
+	case len(e.Names) > 0:
+		for _, name := range e.Names {
+			entry, ok := indexMap[name]
+			if !ok {
+				return nil, notFoundf("entry %s not found", name)
+			}
+			entries = append(entries, entry)
+		}
+
+	default:
+		return nil, invalidArgumentf("nothing to embed: expected all entries, a type or names of entries")
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries, nil
+}
+
+// embeddingUpToDate reports whether a chunk has an embedding of its current text computed by the model.
+func embeddingUpToDate(chunk *pb.Chunk, model string) bool {
+	embedding := chunk.GetEmbedding()
+	return embedding != nil && embedding.Model == model && embedding.Digest == chunk.Digest && len(embedding.Vector) > 0
+}
+
+// embedChunks embeds chunks in batches of batchSize with up to workers batches in flight, reporting progress
+// after each batch. It stops at the first error and returns how many chunks were embedded.
+func embedChunks(ctx context.Context, model embedder.Embedder, chunks []*pb.Chunk, batchSize int, workers int, w io.Writer) (int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	batches := make(chan []*pb.Chunk)
+
+	var mu sync.Mutex
+	var firstErr error
+	embedded := 0
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				texts := make([]string, len(batch))
+				for i, chunk := range batch {
+					texts[i] = chunk.Text
+				}
+
+				vectors, err := model.Embed(ctx, texts)
+
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+				} else {
+					for i, chunk := range batch {
+						chunk.Embedding = &pb.Embedding{Model: model.Model(), Digest: chunk.Digest, Vector: vectors[i]}
+					}
+					embedded += len(batch)
+					fmt.Fprintf(w, "Embedded %d/%d chunks\n", embedded, len(chunks))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+send:
+	for start := 0; start < len(chunks); start += batchSize {
+		select {
+		case batches <- chunks[start:min(start+batchSize, len(chunks))]:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(batches)
+	wg.Wait()
+
+	if firstErr == nil && embedded < len(chunks) {
+		return embedded, ctx.Err()
+	}
+	return embedded, firstErr
+}
+
+// carryEmbeddings keeps the embeddings of chunks whose text did not change when an entry is chunked again.
+func carryEmbeddings(previous []*pb.Chunk, chunks []*pb.Chunk) {
+	embeddings := make(map[string]*pb.Embedding)
+	for _, chunk := range previous {
+		if embedding := chunk.GetEmbedding(); embedding != nil && embedding.Digest == chunk.Digest {
+			embeddings[chunk.Digest] = embedding
+		}
+	}
+
+	for _, chunk := range chunks {
+		if embedding, ok := embeddings[chunk.Digest]; ok && chunk.Embedding == nil {
+			chunk.Embedding = embedding
+		}
+	}
+}
+
+// embeddedEntries returns the entries with at least one chunk whose embedding is up to date with the model.
+func embeddedEntries(entries []*pb.IndexListEntry, model string) []*pb.IndexListEntry {
+	var embedded []*pb.IndexListEntry
+	for _, entry := range entries {
+		for _, chunk := range entry.Chunks {
+			if embeddingUpToDate(chunk, model) {
+				embedded = append(embedded, entry)
+				break
+			}
+		}
+	}
+	return embedded
+}
+
+// storeEmbeddings writes embedded entries to the database along with the vectors of their chunks, and makes sure
+// the vectors of the model are indexed with the graph options of the config.
+func storeEmbeddings(ctx context.Context, conn *db.PgxIface, entries []*pb.IndexListEntry, model string, config *pb.Config) error {
+	if err := db.InsertRows(ctx, conn, &pb.Index{Entries: entries}); err != nil {
+		return err
+	}
+
+	if err := db.InitializeVectorSchema(ctx, conn); err != nil {
+		return err
+	}
+
+	if err := db.UpsertChunkEmbeddings(ctx, conn, entries); err != nil {
+		return err
+	}
+
+	dimension := 0
+	for _, entry := range entries {
+		for _, chunk := range entry.Chunks {
+			if embeddingUpToDate(chunk, model) {
+				dimension = len(chunk.Embedding.Vector)
+			}
+		}
+	}
+	if dimension == 0 {
+		return nil
+	}
+
+	options := config.GetVectorIndex()
+	return db.CreateHNSWIndex(ctx, conn, model, dimension, options.GetMetric(), int(options.GetM()), int(options.GetEfConstruction()))
+}
@@ -0,0 +1,173 @@
+package subcommands
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+func TestSubcommandEmbed(t *testing.T) {
+	indexPath := t.TempDir()
+	indexFilePath := path.Join(indexPath, indexFile)
+
+	chunk := func(name string, text string) *pb.Chunk {
+		return &pb.Chunk{EntryName: name, Text: text, Digest: "sha256:" + text}
+	}
+	indexMap := map[string]*pb.IndexListEntry{
+		"/docs/guide.md": {
+			Name:            "/docs/guide.md",
+			ContentMetadata: &pb.ContentMetadata{DataType: pb.DataType_MARKDOWN},
+			Chunks:          []*pb.Chunk{chunk("/docs/guide.md", "install"), chunk("/docs/guide.md", "usage")},
+		},
+		"/docs/notes.txt": {
+			Name:            "/docs/notes.txt",
+			ContentMetadata: &pb.ContentMetadata{DataType: pb.DataType_TEXT},
+			Chunks:          []*pb.Chunk{chunk("/docs/notes.txt", "notes")},
+		},
+	}
+	if err := writeIndex(indexFilePath, indexMap); err != nil {
+		t.Fatalf("Failed to write index: %v", err)
+	}
+
+	var buf bytes.Buffer
+	resp, err := SubcommandEmbed(context.Background(), nil, &pb.EmbedRequest{DataType: "markdown"}, indexPath, &buf)
+	if err != nil {
+		t.Fatalf("SubcommandEmbed() returned unexpected error: %v", err)
+	}
+	if resp.Model != "hash-256" || resp.EmbeddedChunks != 2 || resp.UnchangedChunks != 0 {
+		t.Errorf("Unexpected response: %v", resp)
+	}
+
+	indexMap, err = readIndex(indexFilePath, false)
+	if err != nil {
+		t.Fatalf("Failed to read index: %v", err)
+	}
+	embedding := indexMap["/docs/guide.md"].Chunks[0].Embedding
+	if embedding.GetModel() != "hash-256" || embedding.GetDigest() != "sha256:install" || len(embedding.GetVector()) != 256 {
+		t.Errorf("Unexpected embedding: model %s, digest %s, %d dimensions", embedding.GetModel(), embedding.GetDigest(), len(embedding.GetVector()))
+	}
+	if indexMap["/docs/notes.txt"].Chunks[0].Embedding != nil {
+		t.Error("Expected chunks of other types not to be embedded")
+	}
+
+	// only the chunk whose text changed is embedded again
+	indexMap["/docs/guide.md"].Chunks[1] = chunk("/docs/guide.md", "advanced usage")
+	if err := writeIndex(indexFilePath, indexMap); err != nil {
+		t.Fatalf("Failed to write index: %v", err)
+	}
+
+	resp, err = SubcommandEmbed(context.Background(), nil, &pb.EmbedRequest{All: true, Workers: 2}, indexPath, &buf)
+	if err != nil {
+		t.Fatalf("SubcommandEmbed() returned unexpected error: %v", err)
+	}
+	if resp.EmbeddedChunks != 2 || resp.UnchangedChunks != 1 {
+		t.Errorf("Expected 2 embedded and 1 unchanged chunks, got %v", resp)
+	}
+
+	resp, err = SubcommandEmbed(context.Background(), nil, &pb.EmbedRequest{Names: []string{"/docs/notes.txt"}, Force: true}, indexPath, &buf)
+	if err != nil {
+		t.Fatalf("SubcommandEmbed() returned unexpected error: %v", err)
+	}
+	if resp.EmbeddedChunks != 1 || resp.UnchangedChunks != 0 {
+		t.Errorf("Expected forced chunks to be embedded again, got %v", resp)
+	}
+
+	if _, err := SubcommandEmbed(context.Background(), nil, &pb.EmbedRequest{Names: []string{"/missing"}}, indexPath, &buf); err == nil {
+		t.Error("Expected an error for a missing entry, but got nil")
+	}
+	if _, err := SubcommandEmbed(context.Background(), nil, &pb.EmbedRequest{}, indexPath, &buf); err == nil {
+		t.Error("Expected an error when nothing is selected, but got nil")
+	}
+}
+
+func TestSubcommandEmbed_ProviderError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": {"message": "model not found"}}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	indexPath := t.TempDir()
+	config := `{"embedding": {"provider": "openai", "model": "missing", "baseUrl": "` + server.URL + `"}}`
+	if err := os.WriteFile(path.Join(indexPath, configFile), []byte(config), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	indexMap := map[string]*pb.IndexListEntry{
+		"notes": {Name: "notes", Chunks: []*pb.Chunk{{Text: "notes", Digest: "sha256:notes"}}},
+	}
+	if err := writeIndex(path.Join(indexPath, indexFile), indexMap); err != nil {
+		t.Fatalf("Failed to write index: %v", err)
+	}
+
+	var buf bytes.Buffer
+	_, err := SubcommandEmbed(context.Background(), nil, &pb.EmbedRequest{All: true}, indexPath, &buf)
+	if err == nil || !strings.Contains(err.Error(), "model not found") {
+		t.Errorf("Expected the provider's error, got %v", err)
+	}
+}
+
+func TestSubcommandEmbed_Database(t *testing.T) {
+	ctx, conn, err := setupDatabaseForTesting()
+	if err != nil {
+		t.Fatalf("Failed to set up the database: %v", err)
+	}
+
+	indexPath := t.TempDir()
+	indexMap := map[string]*pb.IndexListEntry{
+		"schema.sql": {
+			Name:            "schema.sql",
+			ContentMetadata: &pb.ContentMetadata{URI: "schema.sql", DataType: pb.DataType_TEXT},
+			Chunks:          []*pb.Chunk{{EntryName: "schema.sql", Text: "create table users", Digest: "sha256:users"}},
+		},
+	}
+	if err := writeIndex(path.Join(indexPath, indexFile), indexMap); err != nil {
+		t.Fatalf("Failed to write index: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := SubcommandEmbed(ctx, &conn, &pb.EmbedRequest{All: true}, indexPath, &buf); err != nil {
+		t.Fatalf("SubcommandEmbed() returned unexpected error: %v", err)
+	}
+
+	// the chunks are up to date, but are stored in the database nonetheless
+	resp, err := SubcommandEmbed(ctx, &conn, &pb.EmbedRequest{All: true, IndexSource: pb.IndexSource_DATABASE}, indexPath, &buf)
+	if err != nil {
+		t.Fatalf("SubcommandEmbed() returned unexpected error: %v", err)
+	}
+	if resp.EmbeddedChunks != 0 || resp.UnchangedChunks != 1 {
+		t.Errorf("Expected the chunk to be unchanged, got %v", resp)
+	}
+
+	search, err := SubcommandSemanticSearch(ctx, &conn, &pb.SemanticSearchRequest{Query: "users", IndexSource: pb.IndexSource_DATABASE}, indexPath, &buf)
+	if err != nil {
+		t.Fatalf("SubcommandSemanticSearch() returned unexpected error: %v", err)
+	}
+	if len(search.Records) != 1 || search.Records[0].Name != "schema.sql" {
+		t.Errorf("Expected schema.sql from the database, got %v", search.Records)
+	}
+}
+
+func TestCarryEmbeddings(t *testing.T) {
+	embedding := &pb.Embedding{Model: "hash-256", Digest: "sha256:a", Vector: []float32{1}}
+	previous := []*pb.Chunk{
+		{Digest: "sha256:a", Embedding: embedding},
+		{Digest: "sha256:b", Embedding: &pb.Embedding{Model: "hash-256", Digest: "sha256:old"}},
+	}
+	chunks := []*pb.Chunk{{Digest: "sha256:b"}, {Digest: "sha256:a"}}
+
+	carryEmbeddings(previous, chunks)
+
+	if chunks[0].Embedding != nil {
+		t.Error("Expected a stale embedding not to be carried over")
+	}
+	if chunks[1].Embedding != embedding {
+		t.Error("Expected the embedding of unchanged text to be carried over")
+	}
+}
@@ -0,0 +1,113 @@
+package subcommands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	db "accretional.com/semantifly/database"
+	"accretional.com/semantifly/llm"
+	"accretional.com/semantifly/prompt"
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+// SubcommandGenerate assembles the context of a question as SubcommandContext does, and asks the language model of
+// the config, or of the request, to answer it with that context. The system prompt and the question are rendered by
+// the request's prompt template, which also renders the context unless the context request names another one. The
+// answer is written to stream as it arrives if stream is not nil.
+func SubcommandGenerate(ctx context.Context, conn *db.PgxIface, g *pb.GenerateRequest, indexPath string, w io.Writer, stream io.Writer) (*pb.GenerateResponse, error) {
+	if strings.TrimSpace(g.Question) == "" {
+		return nil, invalidArgumentf("question is empty")
+	}
+
+	config, err := readConfig(indexPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the config file: %v", err)
+	}
+
+	generation := &pb.GenerationConfig{}
+	if config.GetGeneration() != nil {
+		generation = proto.Clone(config.GetGeneration()).(*pb.GenerationConfig)
+	}
+	if g.Llm != "" {
+		generation.BaseUrl = g.Llm
+	}
+	if g.Model != "" {
+		generation.Model = g.Model
+	}
+
+	client, err := llm.New(generation)
+	if err != nil {
+		return nil, err
+	}
+
+	contextRequest := &pb.ContextRequest{}
+	if g.Context != nil {
+		contextRequest = proto.Clone(g.Context).(*pb.ContextRequest)
+	}
+	contextRequest.Question = g.Question
+	if contextRequest.Template == "" {
+		contextRequest.Template = g.Template
+	}
+
+	t, err := loadTemplate(indexPath, g.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	assembled, err := SubcommandContext(ctx, conn, contextRequest, indexPath, w)
+	if err != nil {
+		return nil, err
+	}
+	if len(assembled.Citations) == 0 {
+		fmt.Fprintf(w, "No chunks related to the question were found, the model answers without context.\n")
+	}
+
+	data := &prompt.Data{Question: g.Question, Context: assembled.Context, Citations: assembled.Citations}
+	system, err := t.System(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render the system prompt: %v", err)
+	}
+	user, err := t.Prompt(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render the prompt: %v", err)
+	}
+
+	messages := []llm.Message{
+		{Role: "system", Content: system},
+		{Role: "user", Content: user},
+	}
+
+	var onDelta func(string) error
+	if stream != nil {
+		onDelta = func(delta string) error {
+			_, err := io.WriteString(stream, delta)
+			return err
+		}
+	}
+
+	text, model, err := client.Chat(ctx, messages, onDelta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate an answer: %v", err)
+	}
+
+	return &pb.GenerateResponse{Text: text, Citations: assembled.Citations, Model: model}, nil
+}
+
+// formatSources lists citations under a "Sources:" heading, one per line, or returns "" without citations.
+func formatSources(citations []*pb.Citation) string {
+	if len(citations) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Sources:\n")
+	for _, citation := range citations {
+		sb.WriteString(prompt.FormatCitation(citation))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
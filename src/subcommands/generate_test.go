@@ -0,0 +1,75 @@
+package subcommands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+func TestSubcommandGenerate(t *testing.T) {
+	var prompt string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Messages []struct {
+				Role    string `json:"role"`
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || len(request.Messages) != 2 {
+			http.Error(w, `{"error": {"message": "unexpected request"}}`, http.StatusBadRequest)
+			return
+		}
+		prompt = request.Messages[1].Content
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, delta := range []string{"SELECT * FROM users ", "ORDER BY created_at LIMIT 1;"} {
+			fmt.Fprintf(w, "data: {\"choices\": [{\"delta\": {\"content\": %q}}]}\n\n", delta)
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	indexPath := t.TempDir()
+	indexMap := map[string]*pb.IndexListEntry{
+		"schema.sql": {Name: "schema.sql", Chunks: []*pb.Chunk{
+			{EntryName: "schema.sql", StartLine: 1, EndLine: 4, Text: "CREATE TABLE users (id INT, created_at TIMESTAMP);"},
+		}},
+	}
+	if err := writeIndex(path.Join(indexPath, indexFile), indexMap); err != nil {
+		t.Fatalf("Failed to write index: %v", err)
+	}
+
+	request := &pb.GenerateRequest{
+		Question: "sql to find the first user",
+		Llm:      server.URL,
+		Context:  &pb.ContextRequest{Search: &pb.SemanticSearchRequest{Mode: pb.SearchMode_LEXICAL}},
+	}
+
+	var buf, stream bytes.Buffer
+	resp, err := SubcommandGenerate(context.Background(), nil, request, indexPath, &buf, &stream)
+	if err != nil {
+		t.Fatalf("SubcommandGenerate() returned unexpected error: %v", err)
+	}
+
+	if resp.Text != "SELECT * FROM users ORDER BY created_at LIMIT 1;" || stream.String() != resp.Text {
+		t.Errorf("Unexpected answer %q, streamed %q", resp.Text, stream.String())
+	}
+	if !strings.Contains(prompt, "[1] schema.sql, lines 1-4\nCREATE TABLE users") || !strings.Contains(prompt, "Question: sql to find the first user") {
+		t.Errorf("Expected the prompt to hold the context and the question, got:\n%s", prompt)
+	}
+	if formatSources(resp.Citations) != "Sources:\n[1] schema.sql, lines 1-4\n" {
+		t.Errorf("Unexpected sources: %q", formatSources(resp.Citations))
+	}
+
+	if _, err := SubcommandGenerate(context.Background(), nil, &pb.GenerateRequest{Question: "q"}, indexPath, &buf, nil); err == nil {
+		t.Error("Expected an error without a language model, but got nil")
+	}
+}
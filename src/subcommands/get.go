@@ -7,7 +7,9 @@ import (
 	"os"
 	"path"
 
+	"accretional.com/semantifly/chunker"
 	db "accretional.com/semantifly/database"
+	extract "accretional.com/semantifly/extractor"
 	fetch "accretional.com/semantifly/fetcher"
 	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
 )
@@ -16,8 +18,47 @@ import (
 //
 //	Get rid of index file. The database call does the same functionality
 func SubcommandGet(ctx context.Context, conn *db.PgxIface, g *pb.GetRequest, indexPath string, w io.Writer) (string, *pb.ContentMetadata, error) {
+	content, metadata, err := getContent(ctx, conn, g, indexPath, w)
+	if err != nil {
+		return "", nil, err
+	}
 
-	var targetMetadata pb.ContentMetadata
+	if metadata.GetDataType() == pb.DataType_CUSTOM {
+		if err := loadTypes(indexPath); err != nil {
+			return "", nil, fmt.Errorf("failed to load types: %v", err)
+		}
+	}
+
+	if g.Chunk != nil {
+		chunk, err := findChunk(indexPath, metadata, g.Name, []byte(content), g.GetChunk())
+		if err != nil {
+			return "", nil, err
+		}
+		return chunk.Text, metadata, nil
+	}
+
+	if g.Section != "" {
+		section, err := findSection(metadata, []byte(content), g.Section)
+		if err != nil {
+			return "", nil, err
+		}
+		return section.Text, metadata, nil
+	}
+
+	if g.Text {
+		text, err := extract.Text(metadata, []byte(content))
+		if err != nil {
+			return "", nil, err
+		}
+		return text, metadata, nil
+	}
+
+	return content, metadata, nil
+}
+
+func getContent(ctx context.Context, conn *db.PgxIface, g *pb.GetRequest, indexPath string, w io.Writer) (string, *pb.ContentMetadata, error) {
+
+	var targetMetadata *pb.ContentMetadata
 
 	switch g.IndexSource {
 	case pb.IndexSource_INDEX_FILE:
@@ -32,14 +73,14 @@ func SubcommandGet(ctx context.Context, conn *db.PgxIface, g *pb.GetRequest, ind
 
 		if targetEntry == nil {
 			fmt.Fprintf(w, "entry '%s' not found in index file %s\n", g.Name, indexFilePath)
-			return "", nil, fmt.Errorf("entry '%s' not found in index file %s", g.Name, indexFilePath)
+			return "", nil, notFoundf("entry '%s' not found in index file %s", g.Name, indexFilePath)
 		}
 
 		if targetEntry.Content != "" {
 			return targetEntry.Content, targetEntry.ContentMetadata, nil
 		}
 
-		targetMetadata = *targetEntry.GetContentMetadata()
+		targetMetadata = targetEntry.GetContentMetadata()
 
 	case pb.IndexSource_DATABASE:
 
@@ -47,16 +88,16 @@ func SubcommandGet(ctx context.Context, conn *db.PgxIface, g *pb.GetRequest, ind
 		if err != nil {
 			return "", nil, fmt.Errorf("failed to get entry from database: %v", err)
 		} else if dbMetadata == nil {
-			return "", nil, fmt.Errorf("entry '%s' not found in database", g.Name)
+			return "", nil, notFoundf("entry '%s' not found in database", g.Name)
 
 		}
 
-		targetMetadata = *dbMetadata
+		targetMetadata = dbMetadata
 	}
 
 	content, err := fetchFromCopy(indexPath, g.Name)
 	if content != nil {
-		return string(content), &targetMetadata, nil
+		return string(content), targetMetadata, nil
 	} else if err != nil && !os.IsNotExist(err) {
 		fmt.Fprintf(w, "failed to read content from copy: %v. Fetching from the source.\n", err)
 	}
@@ -66,5 +107,46 @@ func SubcommandGet(ctx context.Context, conn *db.PgxIface, g *pb.GetRequest, ind
 		return "", nil, fmt.Errorf("failed to read content from source: %v", err)
 	}
 
-	return string(content), &targetMetadata, nil
+	return string(content), targetMetadata, nil
+}
+
+// findSection extracts the sections of content and returns the one with the given path.
+func findSection(metadata *pb.ContentMetadata, content []byte, sectionPath string) (*pb.Section, error) {
+	sections, err := extract.Extract(metadata, content)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, section := range sections {
+		if section.Path == sectionPath {
+			return section, nil
+		}
+	}
+
+	return nil, notFoundf("section '%s' not found", sectionPath)
+}
+
+// findChunk returns the chunk of an entry with the given ordinal. Content is chunked again with the index's
+// config, so that the chunk matches the content returned by get even if the source changed since it was indexed.
+func findChunk(indexPath string, metadata *pb.ContentMetadata, name string, content []byte, ordinal int32) (*pb.Chunk, error) {
+	config, err := readConfig(indexPath)
+	if err != nil {
+		return nil, err
+	}
+
+	sections, err := extract.Extract(metadata, content)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks, err := chunker.Chunk(name, &chunker.Input{URI: metadata.URI, Content: content, Sections: sections}, chunkingConfig(config, metadata))
+	if err != nil {
+		return nil, err
+	}
+
+	if ordinal < 0 || int(ordinal) >= len(chunks) {
+		return nil, notFoundf("chunk %d not found, %s has %d chunks", ordinal, name, len(chunks))
+	}
+
+	return chunks[ordinal], nil
 }
@@ -246,3 +246,80 @@ func TestGet_Database(t *testing.T) {
 		t.Errorf("Failed to validate webpage copy: Expected \"%s\", got \"%s\"", webpageContent, getResp)
 	}
 }
+
+func TestFindSection(t *testing.T) {
+	content := []byte("# Guide\n\nIntro\n\n## Setup\n\nRun the installer.\n")
+
+	section, err := findSection(&pb.ContentMetadata{DataType: pb.DataType_MARKDOWN}, content, "Guide > Setup")
+	if err != nil {
+		t.Fatalf("findSection returned an error: %v", err)
+	}
+
+	expectedText := "Setup\nRun the installer."
+	if section.Text != expectedText {
+		t.Errorf("Expected section text '%s', but got '%s'", expectedText, section.Text)
+	}
+
+	if _, err := findSection(&pb.ContentMetadata{DataType: pb.DataType_MARKDOWN}, content, "Guide > Missing"); err == nil {
+		t.Errorf("Expected an error for a missing section, but got nil")
+	}
+
+	if _, err := findSection(&pb.ContentMetadata{DataType: pb.DataType_TEXT}, content, "Guide"); err == nil {
+		t.Errorf("Expected an error for a data type without sections, but got nil")
+	}
+}
+
+func TestFindChunk(t *testing.T) {
+	indexPath := t.TempDir()
+	config := `{"chunking": {"chunker": "paragraph", "maxTokens": 4}}`
+	if err := os.WriteFile(path.Join(indexPath, configFile), []byte(config), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	content := []byte("First paragraph here.\n\nSecond paragraph here.\n")
+	metadata := &pb.ContentMetadata{URI: "notes.txt", DataType: pb.DataType_TEXT}
+
+	chunk, err := findChunk(indexPath, metadata, "notes", content, 1)
+	if err != nil {
+		t.Fatalf("findChunk returned an error: %v", err)
+	}
+
+	if chunk.Text != "Second paragraph here." || chunk.StartLine != 3 {
+		t.Errorf("Unexpected chunk %q at line %d", chunk.Text, chunk.StartLine)
+	}
+
+	if _, err := findChunk(indexPath, metadata, "notes", content, 2); err == nil {
+		t.Errorf("Expected an error for a missing chunk, but got nil")
+	}
+}
+
+func TestFetchFromCopy_Binary(t *testing.T) {
+	tempDir := t.TempDir()
+
+	testFilePath := path.Join(tempDir, "manual.pdf")
+	binaryContent := []byte{0x25, 0x50, 0x44, 0x46, 0xff, 0xfe, 0x00, 0x01}
+	if err := os.WriteFile(testFilePath, binaryContent, 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	ile := &pb.IndexListEntry{
+		Name: testFilePath,
+		ContentMetadata: &pb.ContentMetadata{
+			URI:      testFilePath,
+			DataType: pb.DataType_PDF,
+		},
+	}
+
+	if err := makeCopy(tempDir, ile); err != nil {
+		t.Fatalf("makeCopy returned an error: %v", err)
+	}
+
+	content, err := fetchFromCopy(tempDir, testFilePath)
+	if err != nil {
+		t.Fatalf("fetchFromCopy returned an error: %v", err)
+	}
+
+	if !bytes.Equal(content, binaryContent) {
+		t.Errorf("Expected copied content %v, but got %v", binaryContent, content)
+	}
+}
@@ -10,12 +10,15 @@ import (
 	"github.com/kljensen/snowball"
 
 	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+	search "accretional.com/semantifly/search"
 	"google.golang.org/protobuf/proto"
 )
 
 type fileOccurrence struct {
 	FileName   string
 	Occurrence int32
+	Sections   []string
+	Chunks     []*pb.Chunk
 }
 
 type occurrenceList []fileOccurrence
@@ -24,7 +27,7 @@ type searchMap map[string]occurrenceList // Search Map maps search terms to the
 // LexicalSearch performs a search in the index for the specified term and returns the top N results ranked by the frequency of the term.
 func SubcommandLexicalSearch(args *pb.LexicalSearchRequest, indexPath string, w io.Writer) ([]fileOccurrence, error) {
 	if args.TopN <= 0 {
-		return nil, fmt.Errorf("topn: %d is an invalid amount", args.TopN)
+		return nil, invalidArgumentf("topn: %d is an invalid amount", args.TopN)
 	}
 	indexFilePath := path.Join(indexPath, indexFile)
 	data, err := os.ReadFile(indexFilePath)
@@ -107,11 +110,37 @@ func SubcommandLexicalSearch(args *pb.LexicalSearchRequest, indexPath string, w
 		combinedResults = combinedResults[:args.TopN]
 	}
 
+	// point results at the sections and chunks containing the term
+	entries := make(map[string]*pb.IndexListEntry, len(index.Entries))
+	for _, entry := range index.Entries {
+		entries[entry.Name] = entry
+	}
+	for i, result := range combinedResults {
+		sections, err := search.MatchingSections(entries[result.FileName], term)
+		if err != nil {
+			return nil, fmt.Errorf("failed to match sections of %s: %w", result.FileName, err)
+		}
+		combinedResults[i].Sections = sections
+
+		chunks, err := search.MatchingChunks(entries[result.FileName], term)
+		if err != nil {
+			return nil, fmt.Errorf("failed to match chunks of %s: %w", result.FileName, err)
+		}
+		combinedResults[i].Chunks = chunks
+	}
+
 	return combinedResults, nil
 }
 
 func PrintSearchResults(results []fileOccurrence, w io.Writer) {
 	for _, result := range results {
-		fmt.Fprintf(w, "File: %s\nOccurrences: %d\n\n", result.FileName, result.Occurrence)
+		fmt.Fprintf(w, "File: %s\nOccurrences: %d\n", result.FileName, result.Occurrence)
+		for _, section := range result.Sections {
+			fmt.Fprintf(w, "Section: %s\n", section)
+		}
+		for _, chunk := range result.Chunks {
+			fmt.Fprintf(w, "Chunk: %d (lines %d-%d)\n", chunk.Ordinal, chunk.StartLine, chunk.EndLine)
+		}
+		fmt.Fprintln(w)
 	}
 }
@@ -186,3 +186,23 @@ func TestPrintSearchResults(t *testing.T) {
 		t.Errorf("Expected output:\n%s\nGot:\n%s", expectedOutput, output)
 	}
 }
+
+func TestPrintSearchResults_Sections(t *testing.T) {
+	results := []fileOccurrence{
+		{
+			FileName:   "service.proto",
+			Occurrence: 2,
+			Sections:   []string{"example.Searcher", "example.Searcher.Search"},
+		},
+	}
+
+	var buf bytes.Buffer
+	PrintSearchResults(results, &buf)
+
+	output := buf.String()
+	expectedOutput := "File: service.proto\nOccurrences: 2\nSection: example.Searcher\nSection: example.Searcher.Search\n\n"
+
+	if output != expectedOutput {
+		t.Errorf("Expected output:\n%s\nGot:\n%s", expectedOutput, output)
+	}
+}
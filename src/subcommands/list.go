@@ -0,0 +1,221 @@
+package subcommands
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+// SubcommandList returns the entries of the index matching the filters of the request, sorted and paginated.
+// Page tokens are opaque to callers and only valid for the same filters and sort order.
+func SubcommandList(l *pb.ListRequest, indexPath string, w io.Writer) (*pb.ListResponse, error) {
+	indexMap, err := readIndex(path.Join(indexPath, indexFile), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the index file: %v", err)
+	}
+
+	match, err := listFilter(l)
+	if err != nil {
+		return nil, err
+	}
+
+	less, err := listOrder(l.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*pb.IndexListEntry
+	for _, entry := range indexMap {
+		if match(entry) {
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if l.Descending {
+			a, b = b, a
+		}
+		if less(a, b) != less(b, a) {
+			return less(a, b)
+		}
+		return a.Name < b.Name
+	})
+
+	offset := 0
+	if l.PageToken != "" {
+		offset, err = strconv.Atoi(l.PageToken)
+		if err != nil || offset < 0 || offset > len(entries) {
+			return nil, invalidArgumentf("invalid page token %s", l.PageToken)
+		}
+	}
+
+	end := len(entries)
+	if l.PageSize > 0 && offset+int(l.PageSize) < end {
+		end = offset + int(l.PageSize)
+	}
+
+	resp := &pb.ListResponse{}
+	for _, entry := range entries[offset:end] {
+		resp.Entries = append(resp.Entries, &pb.DataDescription{
+			Name:              entry.Name,
+			ContentMetadata:   entry.ContentMetadata,
+			FirstAddedTime:    entry.FirstAddedTime,
+			LastRefreshedTime: entry.LastRefreshedTime,
+			Size:              entry.Size,
+			Digest:            entry.Digest,
+			SectionCount:      int32(len(entry.Sections)),
+			DistinctTerms:     int32(len(entry.WordOccurrences)),
+			ChunkCount:        int32(len(entry.Chunks)),
+		})
+	}
+	if end < len(entries) {
+		resp.NextPageToken = strconv.Itoa(end)
+	}
+
+	return resp, nil
+}
+
+// listFilter returns a function reporting whether an entry matches all of the filters of a request.
+func listFilter(l *pb.ListRequest) (func(*pb.IndexListEntry) bool, error) {
+	var filters []func(*pb.IndexListEntry) bool
+
+	if l.DataType != "" {
+		if val, ok := pb.DataType_value[strings.ToUpper(l.DataType)]; ok {
+			filters = append(filters, func(e *pb.IndexListEntry) bool {
+				return e.GetContentMetadata().GetDataType() == pb.DataType(val)
+			})
+		} else {
+			filters = append(filters, func(e *pb.IndexListEntry) bool {
+				return e.GetContentMetadata().GetDataType() == pb.DataType_CUSTOM && e.GetContentMetadata().GetTypeName() == l.DataType
+			})
+		}
+	}
+
+	if l.DataSource != "" {
+		filters = append(filters, func(e *pb.IndexListEntry) bool {
+			return e.GetContentMetadata().GetDataSource() == l.DataSource
+		})
+	}
+
+	if l.SourceType != "" {
+		sourceType, err := parseSourceType(l.SourceType)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, func(e *pb.IndexListEntry) bool {
+			return e.GetContentMetadata().GetSourceType() == sourceType
+		})
+	}
+
+	if l.StaleSince != nil {
+		cutoff := time.Now().Add(-l.StaleSince.AsDuration())
+		filters = append(filters, func(e *pb.IndexListEntry) bool {
+			return lastRefreshed(e).Before(cutoff)
+		})
+	}
+
+	return func(e *pb.IndexListEntry) bool {
+		for _, filter := range filters {
+			if !filter(e) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// listOrder returns the comparison for a sort field of a ListRequest.
+func listOrder(field string) (func(a, b *pb.IndexListEntry) bool, error) {
+	switch field {
+	case "", "name":
+		return func(a, b *pb.IndexListEntry) bool { return a.Name < b.Name }, nil
+	case "added":
+		return func(a, b *pb.IndexListEntry) bool {
+			return a.GetFirstAddedTime().AsTime().Before(b.GetFirstAddedTime().AsTime())
+		}, nil
+	case "refreshed":
+		return func(a, b *pb.IndexListEntry) bool { return lastRefreshed(a).Before(lastRefreshed(b)) }, nil
+	case "size":
+		return func(a, b *pb.IndexListEntry) bool { return a.Size < b.Size }, nil
+	default:
+		return nil, invalidArgumentf("unknown sort field %s, expected name, added, refreshed or size", field)
+	}
+}
+
+// lastRefreshed returns when an entry was last refreshed, or when it was added if it never was.
+func lastRefreshed(e *pb.IndexListEntry) time.Time {
+	if e.LastRefreshedTime != nil {
+		return e.LastRefreshedTime.AsTime()
+	}
+	return e.GetFirstAddedTime().AsTime()
+}
+
+// parseAge parses a duration like time.ParseDuration, also accepting whole days, eg. "7d".
+func parseAge(str string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(str, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %s", str)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(str)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %s: %v", str, err)
+	}
+	return d, nil
+}
+
+// printList prints listed entries as a table, as JSON lines, or as plain names for scripting.
+func printList(w io.Writer, entries []*pb.DataDescription, format string) error {
+	switch format {
+	case "names":
+		for _, e := range entries {
+			fmt.Fprintln(w, e.Name)
+		}
+
+	case "jsonl":
+		for _, e := range entries {
+			data, err := protojson.Marshal(e)
+			if err != nil {
+				return fmt.Errorf("failed to marshal entry %s: %v", e.Name, err)
+			}
+			fmt.Fprintln(w, string(data))
+		}
+
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "NAME\tTYPE\tSOURCE\tDATASOURCE\tSIZE\tREFRESHED")
+		for _, e := range entries {
+			metadata := e.GetContentMetadata()
+			dataType := metadata.GetDataType().String()
+			if metadata.GetDataType() == pb.DataType_CUSTOM {
+				dataType = metadata.GetTypeName()
+			}
+
+			refreshed := e.LastRefreshedTime
+			if refreshed == nil {
+				refreshed = e.FirstAddedTime
+			}
+
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%s\n", e.Name, dataType, metadata.GetSourceType(), metadata.GetDataSource(), e.Size, formatTimestamp(refreshed))
+		}
+		return tw.Flush()
+
+	default:
+		return fmt.Errorf("unknown format %s, expected table, jsonl or names", format)
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package subcommands
+
+import (
+	"bytes"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestSubcommandList(t *testing.T) {
+	indexPath := t.TempDir()
+	now := time.Now()
+
+	entries := []*pb.IndexListEntry{
+		{
+			Name:              "/docs/a.md",
+			ContentMetadata:   &pb.ContentMetadata{URI: "/docs/a.md", DataType: pb.DataType_MARKDOWN, DataSource: "docs"},
+			FirstAddedTime:    timestamppb.New(now.Add(-30 * 24 * time.Hour)),
+			LastRefreshedTime: timestamppb.New(now.Add(-time.Hour)),
+			Size:              300,
+		},
+		{
+			Name:            "/docs/b.md",
+			ContentMetadata: &pb.ContentMetadata{URI: "/docs/b.md", DataType: pb.DataType_MARKDOWN, DataSource: "docs"},
+			FirstAddedTime:  timestamppb.New(now.Add(-10 * 24 * time.Hour)),
+			Size:            100,
+		},
+		{
+			Name:            "https://example.com/c",
+			ContentMetadata: &pb.ContentMetadata{URI: "https://example.com/c", SourceType: pb.SourceType_WEBPAGE, DataSource: "web"},
+			FirstAddedTime:  timestamppb.New(now.Add(-2 * 24 * time.Hour)),
+			Size:            200,
+		},
+		{
+			Name:            "/data/d.bin",
+			ContentMetadata: &pb.ContentMetadata{URI: "/data/d.bin", DataType: pb.DataType_CUSTOM, TypeName: "SuperCerealized"},
+			FirstAddedTime:  timestamppb.New(now.Add(-20 * 24 * time.Hour)),
+			Size:            50,
+		},
+	}
+
+	indexMap := make(map[string]*pb.IndexListEntry)
+	for _, e := range entries {
+		indexMap[e.Name] = e
+	}
+	if err := writeIndex(path.Join(indexPath, indexFile), indexMap); err != nil {
+		t.Fatalf("Failed to write index: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		request  *pb.ListRequest
+		expected []string
+	}{
+		{"all by name", &pb.ListRequest{}, []string{"/data/d.bin", "/docs/a.md", "/docs/b.md", "https://example.com/c"}},
+		{"by type", &pb.ListRequest{DataType: "markdown"}, []string{"/docs/a.md", "/docs/b.md"}},
+		{"by custom type", &pb.ListRequest{DataType: "SuperCerealized"}, []string{"/data/d.bin"}},
+		{"by data source", &pb.ListRequest{DataSource: "web"}, []string{"https://example.com/c"}},
+		{"by source type", &pb.ListRequest{SourceType: "webpage"}, []string{"https://example.com/c"}},
+		{"stale", &pb.ListRequest{StaleSince: durationpb.New(7 * 24 * time.Hour)}, []string{"/data/d.bin", "/docs/b.md"}},
+		{"by size", &pb.ListRequest{Sort: "size"}, []string{"/data/d.bin", "/docs/b.md", "https://example.com/c", "/docs/a.md"}},
+		{"by refreshed descending", &pb.ListRequest{Sort: "refreshed", Descending: true}, []string{"/docs/a.md", "https://example.com/c", "/docs/b.md", "/data/d.bin"}},
+	}
+
+	var buf bytes.Buffer
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := SubcommandList(tt.request, indexPath, &buf)
+			if err != nil {
+				t.Fatalf("SubcommandList() returned unexpected error: %v", err)
+			}
+			if names := listedNames(resp); !reflect.DeepEqual(names, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, names)
+			}
+		})
+	}
+
+	// pages continue where the previous one ended
+	var names []string
+	request := &pb.ListRequest{PageSize: 3}
+	for {
+		resp, err := SubcommandList(request, indexPath, &buf)
+		if err != nil {
+			t.Fatalf("SubcommandList() returned unexpected error: %v", err)
+		}
+		names = append(names, listedNames(resp)...)
+		if resp.NextPageToken == "" {
+			break
+		}
+		request.PageToken = resp.NextPageToken
+	}
+	if !reflect.DeepEqual(names, tests[0].expected) {
+		t.Errorf("Expected paginated listing %v, got %v", tests[0].expected, names)
+	}
+
+	if _, err := SubcommandList(&pb.ListRequest{Sort: "color"}, indexPath, &buf); err == nil {
+		t.Error("Expected an error for an unknown sort field, but got nil")
+	}
+	if _, err := SubcommandList(&pb.ListRequest{PageToken: "bogus"}, indexPath, &buf); err == nil {
+		t.Error("Expected an error for an invalid page token, but got nil")
+	}
+}
+
+func TestPrintList(t *testing.T) {
+	entries := []*pb.DataDescription{
+		{Name: "/docs/a.md", ContentMetadata: &pb.ContentMetadata{DataType: pb.DataType_MARKDOWN, DataSource: "docs"}, Size: 300},
+		{Name: "/docs/b.txt", ContentMetadata: &pb.ContentMetadata{}},
+	}
+
+	var buf bytes.Buffer
+	if err := printList(&buf, entries, "names"); err != nil {
+		t.Fatalf("printList() returned unexpected error: %v", err)
+	}
+	if buf.String() != "/docs/a.md\n/docs/b.txt\n" {
+		t.Errorf("Unexpected names output:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := printList(&buf, entries, "jsonl"); err != nil {
+		t.Fatalf("printList() returned unexpected error: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 2 || !strings.Contains(lines[0], `"dataSource":"docs"`) {
+		t.Errorf("Unexpected jsonl output:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := printList(&buf, entries, "table"); err != nil {
+		t.Fatalf("printList() returned unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "NAME") || !strings.Contains(buf.String(), "MARKDOWN") {
+		t.Errorf("Unexpected table output:\n%s", buf.String())
+	}
+
+	if err := printList(&buf, entries, "xml"); err == nil {
+		t.Error("Expected an error for an unknown format, but got nil")
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	for input, expected := range map[string]time.Duration{"7d": 7 * 24 * time.Hour, "12h": 12 * time.Hour, "90m": 90 * time.Minute} {
+		got, err := parseAge(input)
+		if err != nil || got != expected {
+			t.Errorf("parseAge(%q) = %v, %v, want %v", input, got, err, expected)
+		}
+	}
+
+	if _, err := parseAge("soon"); err == nil {
+		t.Error("Expected an error for an invalid duration, but got nil")
+	}
+}
+
+func listedNames(resp *pb.ListResponse) []string {
+	var names []string
+	for _, e := range resp.Entries {
+		names = append(names, e.Name)
+	}
+	return names
+}
@@ -0,0 +1,272 @@
+package subcommands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	db "accretional.com/semantifly/database"
+	"accretional.com/semantifly/mcp"
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+const (
+	mcpVersion = "0.1.0"
+
+	// entryURIPrefix starts the URIs of the resources of entries, followed by the escaped entry name
+	entryURIPrefix = "semantifly://entries/"
+
+	defaultMCPSearchResults = 10
+)
+
+const mcpInstructions = `Semantifly indexes documents, code, schemas and API definitions. Use "query" to find the chunks most related to a question, "search" to find the entries containing a term, "list" to browse the entries, and "get" to read an entry, one of its sections or one of its chunks.`
+
+// newMCPServer returns an MCP server whose tools call the subcommands on the index, and whose resources are the
+// entries of the index.
+func newMCPServer(conn *db.PgxIface, indexPath string) *mcp.Server {
+	return &mcp.Server{
+		Name:         "semantifly",
+		Version:      mcpVersion,
+		Instructions: mcpInstructions,
+		Tools: []mcp.Tool{
+			{
+				Name:        "query",
+				Description: "Find the chunks of the index most related to a query, by meaning, words or both",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"query": {"type": "string", "description": "Text to find related chunks for"},
+						"max_records": {"type": "integer", "description": "Maximum number of chunks to return, 10 by default"},
+						"max_size": {"type": "integer", "description": "Maximum total size in bytes of the chunks, unlimited by default"},
+						"mode": {"type": "string", "enum": ["SEMANTIC", "LEXICAL", "HYBRID"], "description": "How chunks are retrieved, SEMANTIC by default"},
+						"rerank": {"type": "boolean", "description": "Rerank the chunks with the reranker of the index"},
+						"diversify": {"type": "boolean", "description": "Favor chunks unlike those already returned"}
+					},
+					"required": ["query"]
+				}`),
+				Call: func(ctx context.Context, arguments json.RawMessage) (string, error) {
+					req := &pb.SemanticSearchRequest{}
+					if err := unmarshalArguments(arguments, req); err != nil {
+						return "", err
+					}
+
+					var buf bytes.Buffer
+					resp, err := SubcommandSemanticSearch(ctx, conn, req, indexPath, &buf)
+					if err != nil {
+						return "", err
+					}
+					if len(resp.Records) == 0 {
+						buf.WriteString("No related chunks were found.\n")
+					}
+					printSearchRecords(&buf, resp.Records)
+					return buf.String(), nil
+				},
+			},
+			{
+				Name:        "search",
+				Description: "Find the entries of the index containing a term, with the sections containing it, most occurrences first",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"search_term": {"type": "string", "description": "Word to search for"},
+						"top_n": {"type": "integer", "description": "Maximum number of entries to return, 10 by default"}
+					},
+					"required": ["search_term"]
+				}`),
+				Call: func(ctx context.Context, arguments json.RawMessage) (string, error) {
+					req := &pb.LexicalSearchRequest{}
+					if err := unmarshalArguments(arguments, req); err != nil {
+						return "", err
+					}
+					if req.TopN == 0 {
+						req.TopN = defaultMCPSearchResults
+					}
+
+					var buf bytes.Buffer
+					results, err := SubcommandLexicalSearch(req, indexPath, &buf)
+					if err != nil {
+						return "", err
+					}
+					if len(results) == 0 {
+						buf.WriteString("No entries contain the term.\n")
+					}
+					PrintSearchResults(results, &buf)
+					return buf.String(), nil
+				},
+			},
+			{
+				Name:        "get",
+				Description: "Read the content of an entry of the index, or of one of its sections or chunks",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"name": {"type": "string", "description": "Name of the entry, as returned by list, search or query"},
+						"section": {"type": "string", "description": "Path of a section of the entry to read alone"},
+						"chunk": {"type": "integer", "description": "Ordinal of a chunk of the entry to read alone"},
+						"text": {"type": "boolean", "description": "Return the text extracted from the content rather than the raw content, eg. for PDFs"}
+					},
+					"required": ["name"]
+				}`),
+				Call: func(ctx context.Context, arguments json.RawMessage) (string, error) {
+					req := &pb.GetRequest{}
+					if err := unmarshalArguments(arguments, req); err != nil {
+						return "", err
+					}
+
+					var buf bytes.Buffer
+					content, _, err := SubcommandGet(ctx, conn, req, indexPath, &buf)
+					if err != nil {
+						return "", err
+					}
+					return content, nil
+				},
+			},
+			{
+				Name:        "list",
+				Description: "List the entries of the index with their type, source, size and refresh time",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"data_type": {"type": "string", "description": "Only list entries of this type, eg. MARKDOWN or a user defined type"},
+						"data_source": {"type": "string", "description": "Only list entries from this data source"},
+						"source_type": {"type": "string", "description": "Only list entries with this source type, eg. webpage"},
+						"stale_since": {"type": "string", "description": "Only list entries not refreshed for this long, in seconds, eg. 86400s"},
+						"sort": {"type": "string", "enum": ["name", "added", "refreshed", "size"]},
+						"descending": {"type": "boolean"},
+						"page_size": {"type": "integer", "description": "Maximum number of entries to return, all by default"},
+						"page_token": {"type": "string", "description": "Token of the next page, as returned by a previous call"}
+					}
+				}`),
+				Call: func(ctx context.Context, arguments json.RawMessage) (string, error) {
+					req := &pb.ListRequest{}
+					if err := unmarshalArguments(arguments, req); err != nil {
+						return "", err
+					}
+
+					var buf bytes.Buffer
+					resp, err := SubcommandList(req, indexPath, &buf)
+					if err != nil {
+						return "", err
+					}
+					if err := printList(&buf, resp.Entries, "table"); err != nil {
+						return "", err
+					}
+					if resp.NextPageToken != "" {
+						fmt.Fprintf(&buf, "Next page token: %s\n", resp.NextPageToken)
+					}
+					return buf.String(), nil
+				},
+			},
+		},
+		Resources: &entryResources{conn: conn, indexPath: indexPath},
+		ResourceTemplates: []mcp.ResourceTemplate{
+			{
+				URITemplate: entryURIPrefix + "{name}",
+				Name:        "entry",
+				Description: "Content of an entry of the index, by its escaped name",
+			},
+		},
+	}
+}
+
+// unmarshalArguments decodes the arguments of a tool into the request of its subcommand. Arguments are named after
+// the fields of the request, and unknown ones are ignored.
+func unmarshalArguments(arguments json.RawMessage, req proto.Message) error {
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(arguments, req); err != nil {
+		return fmt.Errorf("invalid arguments: %v", err)
+	}
+	return nil
+}
+
+// entryResources exposes the entries of the index as MCP resources.
+type entryResources struct {
+	conn      *db.PgxIface
+	indexPath string
+}
+
+func (e *entryResources) ListResources(ctx context.Context) ([]mcp.Resource, error) {
+	indexMap, err := readIndex(path.Join(e.indexPath, indexFile), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the index file: %v", err)
+	}
+
+	resources := make([]mcp.Resource, 0, len(indexMap))
+	for _, entry := range indexMap {
+		resources = append(resources, mcp.Resource{
+			URI:         entryURI(entry.Name),
+			Name:        entry.Name,
+			Description: fmt.Sprintf("%s from %s", typeName(entry.ContentMetadata), strings.ToLower(entry.GetContentMetadata().GetSourceType().String())),
+			MimeType:    mimeType(entry.ContentMetadata),
+			Size:        entry.Size,
+		})
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].Name < resources[j].Name })
+
+	return resources, nil
+}
+
+func (e *entryResources) ReadResource(ctx context.Context, uri string) (*mcp.ResourceContents, error) {
+	if !strings.HasPrefix(uri, entryURIPrefix) {
+		return nil, fmt.Errorf("%w: %s", mcp.ErrResourceNotFound, uri)
+	}
+	name, err := url.PathUnescape(strings.TrimPrefix(uri, entryURIPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", mcp.ErrResourceNotFound, uri)
+	}
+
+	indexMap, err := readIndex(path.Join(e.indexPath, indexFile), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the index file: %v", err)
+	}
+	entry, ok := indexMap[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", mcp.ErrResourceNotFound, uri)
+	}
+
+	// binary content, such as PDFs and encoded messages of user defined types, is read as the text extracted from it
+	dataType := entry.GetContentMetadata().GetDataType()
+	req := &pb.GetRequest{Name: name, Text: dataType == pb.DataType_PDF || dataType == pb.DataType_CUSTOM}
+
+	var buf bytes.Buffer
+	content, metadata, err := SubcommandGet(ctx, e.conn, req, e.indexPath, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	mime := mimeType(metadata)
+	if req.Text {
+		mime = "text/plain"
+	}
+	return &mcp.ResourceContents{URI: uri, MimeType: mime, Text: content}, nil
+}
+
+// entryURI returns the URI of the resource of an entry, eg. "semantifly://entries/%2Fdocs%2Fguide.md".
+func entryURI(name string) string {
+	return entryURIPrefix + url.PathEscape(name)
+}
+
+var mimeTypes = map[pb.DataType]string{
+	pb.DataType_TEXT:     "text/plain",
+	pb.DataType_PROTO:    "text/x-protobuf",
+	pb.DataType_OPENAPI:  "application/vnd.oai.openapi",
+	pb.DataType_MARKDOWN: "text/markdown",
+	pb.DataType_IPYNB:    "application/x-ipynb+json",
+	pb.DataType_PDF:      "application/pdf",
+	pb.DataType_JSON:     "application/json",
+	pb.DataType_YAML:     "application/yaml",
+	pb.DataType_TOML:     "application/toml",
+	pb.DataType_CUSTOM:   "application/octet-stream",
+}
+
+// mimeType returns the MIME type of content with the given metadata.
+func mimeType(metadata *pb.ContentMetadata) string {
+	return mimeTypes[metadata.GetDataType()]
+}
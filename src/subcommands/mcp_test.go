@@ -0,0 +1,111 @@
+package subcommands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"testing"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+// mcpCall sends a request to an MCP server and decodes its result into result.
+func mcpCall(t *testing.T, indexPath string, method string, params string, result any) {
+	t.Helper()
+
+	message := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": %q, "params": %s}`, method, params)
+	reply := newMCPServer(nil, indexPath).Handle(context.Background(), []byte(message))
+
+	var resp struct {
+		Result json.RawMessage
+		Error  *struct{ Message string }
+	}
+	if err := json.Unmarshal(reply, &resp); err != nil {
+		t.Fatalf("Failed to decode the response to %s: %v", method, err)
+	}
+	if resp.Error != nil {
+		t.Fatalf("%s returned unexpected error: %s", method, resp.Error.Message)
+	}
+	if err := json.Unmarshal(resp.Result, result); err != nil {
+		t.Fatalf("Failed to decode the result of %s: %v", method, err)
+	}
+}
+
+type mcpToolResult struct {
+	Content []struct{ Text string }
+	IsError bool
+}
+
+func TestMCPServer(t *testing.T) {
+	indexPath := t.TempDir()
+	indexMap := map[string]*pb.IndexListEntry{
+		"/docs/schema.sql": {
+			Name:            "/docs/schema.sql",
+			ContentMetadata: &pb.ContentMetadata{DataType: pb.DataType_TEXT},
+			Content:         "CREATE TABLE users (id INT, created_at TIMESTAMP);",
+			WordOccurrences: map[string]int32{"users": 1, "table": 1},
+			Size:            50,
+			Chunks: []*pb.Chunk{
+				{EntryName: "/docs/schema.sql", StartLine: 1, EndLine: 1, Text: "CREATE TABLE users (id INT, created_at TIMESTAMP);"},
+			},
+		},
+		"guide.md": {
+			Name:            "guide.md",
+			ContentMetadata: &pb.ContentMetadata{DataType: pb.DataType_MARKDOWN},
+			Content:         "# Guide\nRun the server.",
+			WordOccurrences: map[string]int32{"guide": 1, "server": 1},
+			Chunks: []*pb.Chunk{
+				{EntryName: "guide.md", StartLine: 1, EndLine: 2, Text: "# Guide\nRun the server."},
+			},
+		},
+	}
+	if err := writeIndex(path.Join(indexPath, indexFile), indexMap); err != nil {
+		t.Fatalf("Failed to write index: %v", err)
+	}
+
+	tests := []struct {
+		tool      string
+		arguments string
+		expected  string
+	}{
+		{"query", `{"query": "users table", "mode": "LEXICAL", "max_records": 1}`, "== /docs/schema.sql, chunk 0, lines 1-1"},
+		{"search", `{"search_term": "server"}`, "File: guide.md\nOccurrences: 1"},
+		{"get", `{"name": "guide.md"}`, "# Guide\nRun the server."},
+		{"list", `{"data_type": "MARKDOWN"}`, "guide.md"},
+	}
+
+	for _, tt := range tests {
+		var result mcpToolResult
+		mcpCall(t, indexPath, "tools/call", fmt.Sprintf(`{"name": %q, "arguments": %s}`, tt.tool, tt.arguments), &result)
+		if result.IsError || len(result.Content) != 1 || !strings.Contains(result.Content[0].Text, tt.expected) {
+			t.Errorf("%s: expected a result containing %q, got %+v", tt.tool, tt.expected, result)
+		}
+		if tt.tool == "list" && strings.Contains(result.Content[0].Text, "schema.sql") {
+			t.Errorf("list: expected only markdown entries, got:\n%s", result.Content[0].Text)
+		}
+	}
+
+	var result mcpToolResult
+	mcpCall(t, indexPath, "tools/call", `{"name": "get", "arguments": {"name": "missing.md"}}`, &result)
+	if !result.IsError {
+		t.Errorf("Expected an error result for a missing entry, got %+v", result)
+	}
+
+	var resources struct {
+		Resources []struct{ URI, Name, MimeType string }
+	}
+	mcpCall(t, indexPath, "resources/list", `{}`, &resources)
+	if len(resources.Resources) != 2 || resources.Resources[0].URI != "semantifly://entries/%2Fdocs%2Fschema.sql" || resources.Resources[1].MimeType != "text/markdown" {
+		t.Fatalf("Unexpected resources %+v", resources.Resources)
+	}
+
+	var read struct {
+		Contents []struct{ URI, MimeType, Text string }
+	}
+	mcpCall(t, indexPath, "resources/read", fmt.Sprintf(`{"uri": %q}`, resources.Resources[0].URI), &read)
+	if len(read.Contents) != 1 || read.Contents[0].Text != indexMap["/docs/schema.sql"].Content || read.Contents[0].MimeType != "text/plain" {
+		t.Errorf("Unexpected contents %+v", read.Contents)
+	}
+}
@@ -119,3 +119,23 @@ func TestParseArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestParseExtractOptions(t *testing.T) {
+	options, err := parseExtractOptions("outputs=true, level = 2,empty=")
+	if err != nil {
+		t.Fatalf("parseExtractOptions returned an error: %v", err)
+	}
+
+	expected := map[string]string{"outputs": "true", "level": "2", "empty": ""}
+	if !reflect.DeepEqual(options, expected) {
+		t.Errorf("Expected options %v, got %v", expected, options)
+	}
+
+	if options, err := parseExtractOptions(""); err != nil || options != nil {
+		t.Errorf("Expected no options for an empty string, got %v, %v", options, err)
+	}
+
+	if _, err := parseExtractOptions("outputs"); err == nil {
+		t.Errorf("Expected an error for an option without a value, but got nil")
+	}
+}
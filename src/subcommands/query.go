@@ -0,0 +1,526 @@
+package subcommands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/proto"
+
+	db "accretional.com/semantifly/database"
+	"accretional.com/semantifly/diversify"
+	"accretional.com/semantifly/embedder"
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+	"accretional.com/semantifly/reranker"
+	search "accretional.com/semantifly/search"
+)
+
+const (
+	defaultMaxRecords = 10
+
+	// rrfRankConstant dampens the weight of top ranks in reciprocal rank fusion, 60 being the usual value
+	rrfRankConstant = 60
+
+	// candidateFactor is how many more chunks than records are retrieved, leaving room for fusing rankings and
+	// leaving out duplicates
+	candidateFactor = 3
+)
+
+// SubcommandSemanticSearch returns the chunks most related to the query, most relevant first, within the record and
+// size budgets of the request. Semantic searches embed the query with the model of the index's config and look up
+// the closest vectors, in the vector index of the index directory or in the database if the request's index source
+// says so. Lexical searches rank the chunks of the index file by the occurrences of the query's words. Hybrid
+// searches run both in parallel and fuse their rankings. The top results are then reranked if requested. Near
+// duplicates are left out unless requested, and the records are diversified if requested.
+func SubcommandSemanticSearch(ctx context.Context, conn *db.PgxIface, s *pb.SemanticSearchRequest, indexPath string, w io.Writer) (*pb.SemanticSearchResponse, error) {
+	if strings.TrimSpace(s.Query) == "" {
+		return nil, invalidArgumentf("query is empty")
+	}
+
+	if s.GetLexicalWeight() < 0 || s.GetSemanticWeight() < 0 {
+		return nil, invalidArgumentf("weights must not be negative, got %g lexical and %g semantic", s.GetLexicalWeight(), s.GetSemanticWeight())
+	}
+
+	if s.GetMmrLambda() < 0 || s.GetMmrLambda() > 1 {
+		return nil, invalidArgumentf("the MMR lambda must be between 0 and 1, got %g", s.GetMmrLambda())
+	}
+
+	maxRecords := int(s.MaxRecords)
+	if maxRecords <= 0 {
+		maxRecords = defaultMaxRecords
+	}
+
+	config, err := readConfig(indexPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the config file: %v", err)
+	}
+
+	k := maxRecords * candidateFactor
+
+	var rerank reranker.Reranker
+	topK := 0
+	if s.Rerank {
+		rerank, err = reranker.New(config.GetReranking())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the reranker: %v", err)
+		}
+
+		topK = int(s.RerankTopK)
+		if topK <= 0 {
+			topK = int(config.GetReranking().GetTopK())
+		}
+		if topK <= 0 {
+			topK = reranker.DefaultTopK
+		}
+		k = max(k, topK)
+	}
+
+	var wg sync.WaitGroup
+	var lexical, semantic []*pb.SearchRecord
+	var lexicalErr, semanticErr error
+	resp := &pb.SemanticSearchResponse{}
+
+	if s.Mode != pb.SearchMode_SEMANTIC {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lexical, lexicalErr = lexicalRecords(indexPath, s.Query, k)
+		}()
+	}
+	if s.Mode != pb.SearchMode_LEXICAL {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semantic, resp.Model, semanticErr = semanticRecords(ctx, conn, s, indexPath, config, k)
+		}()
+	}
+	wg.Wait()
+
+	if lexicalErr != nil {
+		return nil, lexicalErr
+	}
+	if semanticErr != nil {
+		return nil, semanticErr
+	}
+
+	var records []*pb.SearchRecord
+	switch s.Mode {
+	case pb.SearchMode_LEXICAL:
+		records = lexical
+	case pb.SearchMode_HYBRID:
+		records = fuseRankings([][]*pb.SearchRecord{lexical, semantic}, []float64{weight(s.LexicalWeight), weight(s.SemanticWeight)}, s.Fusion)
+	default:
+		records = semantic
+	}
+
+	if rerank != nil {
+		records, err = rerankRecords(ctx, rerank, indexPath, s.Query, records, topK)
+		if err != nil {
+			return nil, fmt.Errorf("failed to rerank with %s: %v", rerank.Name(), err)
+		}
+		resp.Reranker = rerank.Name()
+	}
+
+	if !s.IncludeDuplicates {
+		records = groupDuplicates(records)
+	}
+
+	if s.Diversify {
+		lambda := diversify.DefaultLambda
+		if s.MmrLambda != nil {
+			lambda = float64(*s.MmrLambda)
+		}
+		records, err = diversifyRecords(ctx, config, records, lambda)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp.Records = fitBudget(records, maxRecords, s.MaxSize)
+	return resp, nil
+}
+
+// semanticRecords embeds the query and returns up to k records for the chunks with the closest vectors, along with
+// the id of the model.
+func semanticRecords(ctx context.Context, conn *db.PgxIface, s *pb.SemanticSearchRequest, indexPath string, config *pb.Config, k int) ([]*pb.SearchRecord, string, error) {
+	model, err := embedder.New(config.GetEmbedding())
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create the embedder: %v", err)
+	}
+
+	vectors, err := model.Embed(ctx, []string{s.Query})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to embed the query: %v", err)
+	}
+
+	var records []*pb.SearchRecord
+	switch s.IndexSource {
+	case pb.IndexSource_DATABASE:
+		records, err = searchDatabase(ctx, conn, model.Model(), vectors[0], config, k)
+	default:
+		records, err = searchVectorIndex(indexPath, model.Model(), vectors[0], config, k)
+	}
+
+	return records, model.Model(), err
+}
+
+// lexicalRecords returns up to k records for the chunks of the index file in which the words of the query occur
+// the most, scored by their number of occurrences.
+func lexicalRecords(indexPath string, query string, k int) ([]*pb.SearchRecord, error) {
+	indexMap, err := readIndex(path.Join(indexPath, indexFile), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the index file: %v", err)
+	}
+
+	var records []*pb.SearchRecord
+	for _, entry := range indexMap {
+		for _, chunk := range entry.Chunks {
+			score, err := search.ScoreText(chunk.Text, query)
+			if err != nil {
+				return nil, fmt.Errorf("failed to score chunk %d of %s: %v", chunk.Ordinal, entry.Name, err)
+			}
+			if score > 0 {
+				records = append(records, &pb.SearchRecord{Name: entry.Name, Chunk: chunk, Score: float32(score)})
+			}
+		}
+	}
+
+	sortRecords(records)
+	if len(records) > k {
+		records = records[:k]
+	}
+
+	return records, nil
+}
+
+// rerankRecords reorders the first topK records by the scores of the reranker, which replace their scores.
+// The other records follow in their original order. Documents are dated by when their entry was last refreshed.
+func rerankRecords(ctx context.Context, rerank reranker.Reranker, indexPath string, query string, records []*pb.SearchRecord, topK int) ([]*pb.SearchRecord, error) {
+	head, tail := records[:min(topK, len(records))], records[min(topK, len(records)):]
+
+	indexMap, err := readIndex(path.Join(indexPath, indexFile), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the index file: %v", err)
+	}
+
+	documents := make([]reranker.Document, len(head))
+	for i, record := range head {
+		documents[i].Text = record.Chunk.GetText()
+		if entry, ok := indexMap[record.Name]; ok && entry.LastRefreshedTime != nil {
+			documents[i].Updated = entry.LastRefreshedTime.AsTime()
+		}
+	}
+
+	scores, err := rerank.Rerank(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	reranked := make([]*pb.SearchRecord, 0, len(records))
+	for _, i := range reranker.Order(scores) {
+		head[i].Score = float32(scores[i])
+		reranked = append(reranked, head[i])
+	}
+
+	return append(reranked, tail...), nil
+}
+
+// groupDuplicates leaves out the records of near-duplicate chunks, keeping the most relevant of each group and
+// naming the entries of the others in its duplicates. Chunks indexed without a fingerprint are fingerprinted here.
+func groupDuplicates(records []*pb.SearchRecord) []*pb.SearchRecord {
+	fingerprints := make([]uint64, len(records))
+	for i, record := range records {
+		fingerprints[i] = record.Chunk.GetSimhash()
+		if fingerprints[i] == 0 {
+			fingerprints[i] = diversify.SimHash(record.Chunk.GetText())
+		}
+	}
+
+	var grouped []*pb.SearchRecord
+	for i, group := range diversify.Group(fingerprints) {
+		if group == i {
+			grouped = append(grouped, records[i])
+			continue
+		}
+		representative := records[group]
+		if records[i].Name != representative.Name && !slices.Contains(representative.Duplicates, records[i].Name) {
+			representative.Duplicates = append(representative.Duplicates, records[i].Name)
+		}
+	}
+
+	return grouped
+}
+
+// diversifyRecords reorders records by maximal marginal relevance. The vectors of chunks embedded with the model
+// of the config are used, and the other chunks are embedded here.
+func diversifyRecords(ctx context.Context, config *pb.Config, records []*pb.SearchRecord, lambda float64) ([]*pb.SearchRecord, error) {
+	model, err := embedder.New(config.GetEmbedding())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the embedder: %v", err)
+	}
+
+	vectors := make([][]float32, len(records))
+	var missing []int
+	var texts []string
+	for i, record := range records {
+		if embeddingUpToDate(record.Chunk, model.Model()) {
+			vectors[i] = record.Chunk.Embedding.Vector
+		} else {
+			missing = append(missing, i)
+			texts = append(texts, record.Chunk.GetText())
+		}
+	}
+
+	if len(texts) > 0 {
+		embedded, err := model.Embed(ctx, texts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to embed chunks to diversify: %v", err)
+		}
+		for j, i := range missing {
+			vectors[i] = embedded[j]
+		}
+	}
+
+	relevance := make([]float64, len(records))
+	for i, record := range records {
+		relevance[i] = float64(record.Score)
+	}
+
+	diversified := make([]*pb.SearchRecord, len(records))
+	for j, i := range diversify.MMR(relevance, vectors, lambda) {
+		diversified[j] = records[i]
+	}
+
+	return diversified, nil
+}
+
+// fuseRankings combines rankings of records into one, weighting each ranking. Records of the same chunk are merged,
+// and their score becomes the fused score.
+func fuseRankings(rankings [][]*pb.SearchRecord, weights []float64, fusion pb.Fusion) []*pb.SearchRecord {
+	fused := make(map[string]*pb.SearchRecord)
+	scores := make(map[string]float64)
+
+	for i, ranking := range rankings {
+		low, high := float32(math.Inf(1)), float32(math.Inf(-1))
+		for _, record := range ranking {
+			low, high = min(low, record.Score), max(high, record.Score)
+		}
+
+		for rank, record := range ranking {
+			id := chunkID(record.Name, record.Chunk.GetOrdinal())
+			if _, ok := fused[id]; !ok {
+				fused[id] = &pb.SearchRecord{Name: record.Name, Chunk: record.Chunk}
+			}
+
+			switch fusion {
+			case pb.Fusion_WEIGHTED:
+				normalized := 1.0
+				if high > low {
+					normalized = float64(record.Score-low) / float64(high-low)
+				}
+				scores[id] += weights[i] * normalized
+			default:
+				scores[id] += weights[i] / float64(rrfRankConstant+rank+1)
+			}
+		}
+	}
+
+	records := make([]*pb.SearchRecord, 0, len(fused))
+	for id, record := range fused {
+		record.Score = float32(scores[id])
+		records = append(records, record)
+	}
+
+	sortRecords(records)
+	return records
+}
+
+// sortRecords sorts records by decreasing score, and by entry name and chunk ordinal among equal scores.
+func sortRecords(records []*pb.SearchRecord) {
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Score != records[j].Score {
+			return records[i].Score > records[j].Score
+		}
+		if records[i].Name != records[j].Name {
+			return records[i].Name < records[j].Name
+		}
+		return records[i].Chunk.GetOrdinal() < records[j].Chunk.GetOrdinal()
+	})
+}
+
+// weight returns the weight of a ranking in a hybrid search, 1 if unset.
+func weight(w *float32) float64 {
+	if w == nil {
+		return 1
+	}
+	return float64(*w)
+}
+
+// searchVectorIndex returns up to k records for the chunks of the index closest to the query in the vector index.
+// Vectors of chunks that changed or were deleted since they were indexed are skipped.
+func searchVectorIndex(indexPath string, model string, query []float32, config *pb.Config, k int) ([]*pb.SearchRecord, error) {
+	ix, err := loadVectorIndex(indexPath, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the vector index: %v", err)
+	}
+	if ix == nil {
+		return nil, fmt.Errorf("there is no vector index in %s, run 'semantifly embed --all' first", indexPath)
+	}
+	if ix.Model() != model {
+		return nil, fmt.Errorf("the vector index holds vectors of %s but the config embeds with %s, run 'semantifly embed --all' again", ix.Model(), model)
+	}
+
+	indexMap, err := readIndex(path.Join(indexPath, indexFile), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the index file: %v", err)
+	}
+
+	type indexedChunk struct {
+		name  string
+		chunk *pb.Chunk
+	}
+	chunks := make(map[string]indexedChunk)
+	for _, entry := range indexMap {
+		for _, chunk := range entry.Chunks {
+			chunks[chunkID(entry.Name, chunk.Ordinal)] = indexedChunk{entry.Name, chunk}
+		}
+	}
+
+	// more results than needed are requested, in case some are stale
+	results, err := ix.Search(query, 2*k)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search the vector index: %v", err)
+	}
+
+	var records []*pb.SearchRecord
+	for _, result := range results {
+		c, ok := chunks[result.ID]
+		if !ok || !embeddingUpToDate(c.chunk, model) {
+			continue
+		}
+
+		records = append(records, &pb.SearchRecord{Name: c.name, Chunk: c.chunk, Score: result.Score})
+		if len(records) == k {
+			break
+		}
+	}
+
+	return records, nil
+}
+
+// searchDatabase returns up to k records for the chunks closest to the query among those stored in the database.
+func searchDatabase(ctx context.Context, conn *db.PgxIface, model string, query []float32, config *pb.Config, k int) ([]*pb.SearchRecord, error) {
+	results, err := db.SearchChunkEmbeddings(ctx, conn, model, query, config.GetVectorIndex().GetMetric(), k)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search the database: %v", err)
+	}
+
+	records := make([]*pb.SearchRecord, len(results))
+	for i, result := range results {
+		records[i] = &pb.SearchRecord{Name: result.Chunk.EntryName, Chunk: result.Chunk, Score: float32(result.Score)}
+	}
+
+	return records, nil
+}
+
+// fitBudget keeps the first maxRecords records whose text fits in maxSize bytes, trimming the text of the last
+// one to fill the budget. Returned chunks are copies without their embedding, which callers have no use for.
+func fitBudget(records []*pb.SearchRecord, maxRecords int, maxSize int64) []*pb.SearchRecord {
+	if len(records) > maxRecords {
+		records = records[:maxRecords]
+	}
+
+	remaining := maxSize
+	fitted := make([]*pb.SearchRecord, 0, len(records))
+	for _, record := range records {
+		if maxSize > 0 && remaining <= 0 {
+			break
+		}
+
+		chunk := proto.Clone(record.Chunk).(*pb.Chunk)
+		chunk.Embedding = nil
+		fitted = append(fitted, &pb.SearchRecord{Name: record.Name, Chunk: chunk, Score: record.Score, Duplicates: record.Duplicates})
+
+		if maxSize <= 0 {
+			continue
+		}
+		if int64(len(chunk.Text)) > remaining {
+			chunk.Text = trimUTF8(chunk.Text, int(remaining))
+			fitted[len(fitted)-1].Trimmed = true
+		}
+		remaining -= int64(len(chunk.Text))
+	}
+
+	return fitted
+}
+
+// trimUTF8 cuts text to at most n bytes without splitting a character.
+func trimUTF8(text string, n int) string {
+	if len(text) <= n {
+		return text
+	}
+	for n > 0 && !utf8.RuneStart(text[n]) {
+		n--
+	}
+	return text[:n]
+}
+
+// parseSize parses a size in bytes with an optional unit, eg. "512", "10KB" or "1.5MB". Units are powers of 1024.
+func parseSize(str string) (int64, error) {
+	units := []struct {
+		suffix     string
+		multiplier float64
+	}{
+		{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1},
+	}
+
+	number, multiplier := strings.TrimSpace(strings.ToUpper(str)), 1.0
+	for _, unit := range units {
+		if n, ok := strings.CutSuffix(number, unit.suffix); ok {
+			number, multiplier = strings.TrimSpace(n), unit.multiplier
+			break
+		}
+	}
+
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid size %s, expected eg. 512, 10KB or 1.5MB", str)
+	}
+
+	return int64(value * multiplier), nil
+}
+
+// printSearchRecords prints each record with a header naming its entry, chunk, lines and score, followed by its text.
+func printSearchRecords(w io.Writer, records []*pb.SearchRecord) {
+	for i, record := range records {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+
+		chunk := record.Chunk
+		header := fmt.Sprintf("%s, chunk %d", record.Name, chunk.GetOrdinal())
+		if chunk.GetPath() != "" {
+			header += ", " + chunk.GetPath()
+		}
+		if chunk.GetStartLine() > 0 {
+			header += fmt.Sprintf(", lines %d-%d", chunk.GetStartLine(), chunk.GetEndLine())
+		}
+		header += fmt.Sprintf(", score %.3f", record.Score)
+		if record.Trimmed {
+			header += ", trimmed"
+		}
+		if len(record.Duplicates) > 0 {
+			header += ", duplicated in " + strings.Join(record.Duplicates, ", ")
+		}
+
+		fmt.Fprintf(w, "== %s ==\n%s\n", header, chunk.GetText())
+	}
+}
@@ -0,0 +1,337 @@
+package subcommands
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSubcommandSemanticSearch(t *testing.T) {
+	indexPath := t.TempDir()
+
+	chunk := func(name string, ordinal int32, text string) *pb.Chunk {
+		return &pb.Chunk{EntryName: name, Ordinal: ordinal, Text: text, Digest: "sha256:" + text}
+	}
+	indexMap := map[string]*pb.IndexListEntry{
+		"schema.sql": {Name: "schema.sql", Chunks: []*pb.Chunk{
+			chunk("schema.sql", 0, "create table users with columns for the users database schema"),
+			chunk("schema.sql", 1, "create table orders referencing users"),
+		}},
+		"recipes.txt": {Name: "recipes.txt", Chunks: []*pb.Chunk{
+			chunk("recipes.txt", 0, "bake the bread for forty minutes"),
+		}},
+	}
+	if err := writeIndex(path.Join(indexPath, indexFile), indexMap); err != nil {
+		t.Fatalf("Failed to write index: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := SubcommandSemanticSearch(context.Background(), nil, &pb.SemanticSearchRequest{Query: "users"}, indexPath, &buf); err == nil {
+		t.Error("Expected an error before chunks are embedded, but got nil")
+	}
+
+	if _, err := SubcommandEmbed(context.Background(), nil, &pb.EmbedRequest{All: true}, indexPath, &buf); err != nil {
+		t.Fatalf("SubcommandEmbed() returned unexpected error: %v", err)
+	}
+
+	resp, err := SubcommandSemanticSearch(context.Background(), nil, &pb.SemanticSearchRequest{Query: "users database schema", MaxRecords: 2}, indexPath, &buf)
+	if err != nil {
+		t.Fatalf("SubcommandSemanticSearch() returned unexpected error: %v", err)
+	}
+	if resp.Model != "hash-256" || len(resp.Records) != 2 {
+		t.Fatalf("Expected 2 records of hash-256, got %v", resp)
+	}
+	if resp.Records[0].Name != "schema.sql" || resp.Records[0].Chunk.Ordinal != 0 {
+		t.Errorf("Expected the schema chunk first, got %s#%d", resp.Records[0].Name, resp.Records[0].Chunk.Ordinal)
+	}
+	if resp.Records[0].Score < resp.Records[1].Score {
+		t.Errorf("Expected records by decreasing score, got %f then %f", resp.Records[0].Score, resp.Records[1].Score)
+	}
+	if resp.Records[0].Chunk.Embedding != nil {
+		t.Error("Expected records not to carry embeddings")
+	}
+
+	// the size budget trims the last record that fits
+	resp, err = SubcommandSemanticSearch(context.Background(), nil, &pb.SemanticSearchRequest{Query: "users database schema", MaxSize: 70}, indexPath, &buf)
+	if err != nil {
+		t.Fatalf("SubcommandSemanticSearch() returned unexpected error: %v", err)
+	}
+	size := 0
+	for _, record := range resp.Records {
+		size += len(record.Chunk.Text)
+	}
+	if size != 70 || len(resp.Records) != 2 || !resp.Records[1].Trimmed || resp.Records[0].Trimmed {
+		t.Errorf("Expected 2 records of 70 bytes with the last trimmed, got %d bytes in %v", size, resp.Records)
+	}
+
+	// stale vectors are skipped
+	indexMap["schema.sql"].Chunks[0] = chunk("schema.sql", 0, "the users database schema was rewritten")
+	if err := writeIndex(path.Join(indexPath, indexFile), indexMap); err != nil {
+		t.Fatalf("Failed to write index: %v", err)
+	}
+	resp, err = SubcommandSemanticSearch(context.Background(), nil, &pb.SemanticSearchRequest{Query: "users database schema"}, indexPath, &buf)
+	if err != nil {
+		t.Fatalf("SubcommandSemanticSearch() returned unexpected error: %v", err)
+	}
+	for _, record := range resp.Records {
+		if record.Name == "schema.sql" && record.Chunk.Ordinal == 0 {
+			t.Error("Expected the changed chunk to be skipped until it is embedded again")
+		}
+	}
+
+	if _, err := SubcommandSemanticSearch(context.Background(), nil, &pb.SemanticSearchRequest{Query: " "}, indexPath, &buf); err == nil {
+		t.Error("Expected an error for an empty query, but got nil")
+	}
+}
+
+func TestTrimUTF8(t *testing.T) {
+	if got := trimUTF8("héllo", 2); got != "h" {
+		t.Errorf("trimUTF8() = %q, expected %q", got, "h")
+	}
+	if got := trimUTF8("héllo", 3); got != "hé" {
+		t.Errorf("trimUTF8() = %q, expected %q", got, "hé")
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+		wantErr  bool
+	}{
+		{"512", 512, false},
+		{"10KB", 10 * 1024, false},
+		{"10kb", 10 * 1024, false},
+		{"1.5MB", 3 * 512 * 1024, false},
+		{"2 GB", 2 << 30, false},
+		{"100B", 100, false},
+		{"ten", 0, true},
+		{"-1KB", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseSize(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseSize(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if got != tt.expected {
+			t.Errorf("parseSize(%q) = %d, expected %d", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestPrintSearchRecords(t *testing.T) {
+	var buf bytes.Buffer
+	printSearchRecords(&buf, []*pb.SearchRecord{
+		{Name: "a.go", Chunk: &pb.Chunk{Ordinal: 2, StartLine: 3, EndLine: 9, Text: "func a() {}"}, Score: 0.5, Trimmed: true},
+	})
+	if !strings.HasPrefix(buf.String(), "== a.go, chunk 2, lines 3-9, score 0.500, trimmed ==\nfunc a() {}") {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+}
+
+func TestSubcommandSemanticSearch_Modes(t *testing.T) {
+	indexPath := t.TempDir()
+
+	chunk := func(name string, ordinal int32, text string) *pb.Chunk {
+		return &pb.Chunk{EntryName: name, Ordinal: ordinal, Text: text, Digest: "sha256:" + text}
+	}
+	indexMap := map[string]*pb.IndexListEntry{
+		"config.go": {Name: "config.go", Chunks: []*pb.Chunk{
+			chunk("config.go", 0, "func ParseServerPort reads the listening port"),
+			chunk("config.go", 1, "func ParseTimeout reads the request timeout"),
+		}},
+		"notes.txt": {Name: "notes.txt", Chunks: []*pb.Chunk{
+			chunk("notes.txt", 0, "the server listens on the port from the config"),
+		}},
+	}
+	if err := writeIndex(path.Join(indexPath, indexFile), indexMap); err != nil {
+		t.Fatalf("Failed to write index: %v", err)
+	}
+
+	var buf bytes.Buffer
+
+	// a lexical search needs no vectors, and only returns chunks containing the query's words
+	resp, err := SubcommandSemanticSearch(context.Background(), nil, &pb.SemanticSearchRequest{Query: "ParseServerPort", Mode: pb.SearchMode_LEXICAL}, indexPath, &buf)
+	if err != nil {
+		t.Fatalf("SubcommandSemanticSearch() returned unexpected error: %v", err)
+	}
+	if resp.Model != "" || len(resp.Records) != 1 || resp.Records[0].Name != "config.go" || resp.Records[0].Chunk.Ordinal != 0 {
+		t.Errorf("Expected only the chunk defining ParseServerPort, got %v", resp)
+	}
+
+	if _, err := SubcommandEmbed(context.Background(), nil, &pb.EmbedRequest{All: true}, indexPath, &buf); err != nil {
+		t.Fatalf("SubcommandEmbed() returned unexpected error: %v", err)
+	}
+
+	for _, fusion := range []pb.Fusion{pb.Fusion_RRF, pb.Fusion_WEIGHTED} {
+		resp, err = SubcommandSemanticSearch(context.Background(), nil, &pb.SemanticSearchRequest{
+			Query:  "ParseServerPort listening port",
+			Mode:   pb.SearchMode_HYBRID,
+			Fusion: fusion,
+		}, indexPath, &buf)
+		if err != nil {
+			t.Fatalf("SubcommandSemanticSearch() returned unexpected error: %v", err)
+		}
+		if resp.Model != "hash-256" || len(resp.Records) != 3 {
+			t.Fatalf("Expected 3 records with %v fusion, got %v", fusion, resp)
+		}
+		if resp.Records[0].Name != "config.go" || resp.Records[0].Chunk.Ordinal != 0 {
+			t.Errorf("Expected the chunk matching both rankings first with %v fusion, got %s#%d", fusion, resp.Records[0].Name, resp.Records[0].Chunk.Ordinal)
+		}
+	}
+
+	if _, err := SubcommandSemanticSearch(context.Background(), nil, &pb.SemanticSearchRequest{Query: "port", Mode: pb.SearchMode_HYBRID, LexicalWeight: proto.Float32(-1)}, indexPath, &buf); err == nil {
+		t.Error("Expected an error for a negative weight, but got nil")
+	}
+}
+
+func TestFuseRankings(t *testing.T) {
+	record := func(name string, score float32) *pb.SearchRecord {
+		return &pb.SearchRecord{Name: name, Chunk: &pb.Chunk{}, Score: score}
+	}
+	lexical := []*pb.SearchRecord{record("a", 10), record("b", 5)}
+	semantic := []*pb.SearchRecord{record("b", 0.9), record("c", 0.8), record("a", 0.1)}
+
+	names := func(records []*pb.SearchRecord) string {
+		var names []string
+		for _, record := range records {
+			names = append(names, record.Name)
+		}
+		return strings.Join(names, ",")
+	}
+
+	// b ranks well in both rankings
+	if got := names(fuseRankings([][]*pb.SearchRecord{lexical, semantic}, []float64{1, 1}, pb.Fusion_RRF)); got != "b,a,c" {
+		t.Errorf("Unexpected RRF ranking: %s", got)
+	}
+
+	// a strong lexical weight favours the lexical ranking
+	if got := names(fuseRankings([][]*pb.SearchRecord{lexical, semantic}, []float64{5, 1}, pb.Fusion_RRF)); got != "a,b,c" {
+		t.Errorf("Unexpected weighted RRF ranking: %s", got)
+	}
+
+	// normalized scores: a = 1 + 0, b = 0 + 1, c = 0 + 0.875
+	fused := fuseRankings([][]*pb.SearchRecord{lexical, semantic}, []float64{1, 1}, pb.Fusion_WEIGHTED)
+	if got := names(fused); got != "a,b,c" || fused[0].Score != 1 {
+		t.Errorf("Unexpected weighted ranking: %s, top score %f", got, fused[0].Score)
+	}
+
+	// a weight of 0 leaves the lexical ranking out, only an unset weight is 1
+	weights := []float64{weight(proto.Float32(0)), weight(nil)}
+	if got := names(fuseRankings([][]*pb.SearchRecord{lexical, semantic}, weights, pb.Fusion_WEIGHTED)); got != "b,c,a" {
+		t.Errorf("Unexpected ranking without the lexical ranking: %s", got)
+	}
+}
+
+func TestSubcommandSemanticSearch_Rerank(t *testing.T) {
+	indexPath := t.TempDir()
+
+	indexMap := map[string]*pb.IndexListEntry{
+		"a.md": {Name: "a.md", Chunks: []*pb.Chunk{{EntryName: "a.md", Text: "users users users: the table. Later, the database and its schema"}}},
+		"b.md": {Name: "b.md", Chunks: []*pb.Chunk{{EntryName: "b.md", Text: "the users database schema"}}},
+		"c.md": {Name: "c.md", Chunks: []*pb.Chunk{{EntryName: "c.md", Text: "users"}}},
+	}
+	if err := writeIndex(path.Join(indexPath, indexFile), indexMap); err != nil {
+		t.Fatalf("Failed to write index: %v", err)
+	}
+
+	names := func(records []*pb.SearchRecord) string {
+		var names []string
+		for _, record := range records {
+			names = append(names, record.Name)
+		}
+		return strings.Join(names, ",")
+	}
+
+	var buf bytes.Buffer
+	request := &pb.SemanticSearchRequest{Query: "users database schema", Mode: pb.SearchMode_LEXICAL}
+	resp, err := SubcommandSemanticSearch(context.Background(), nil, request, indexPath, &buf)
+	if err != nil {
+		t.Fatalf("SubcommandSemanticSearch() returned unexpected error: %v", err)
+	}
+	if got := names(resp.Records); got != "a.md,b.md,c.md" || resp.Reranker != "" {
+		t.Errorf("Expected the chunk with the most occurrences first, got %s", got)
+	}
+
+	// the chunk with the query's terms together comes first once reranked
+	request.Rerank = true
+	resp, err = SubcommandSemanticSearch(context.Background(), nil, request, indexPath, &buf)
+	if err != nil {
+		t.Fatalf("SubcommandSemanticSearch() returned unexpected error: %v", err)
+	}
+	if got := names(resp.Records); got != "b.md,a.md,c.md" || resp.Reranker != "heuristic" || resp.Records[0].Score != 1 {
+		t.Errorf("Expected the reranked order b.md,a.md,c.md by heuristic, got %s by %s", got, resp.Reranker)
+	}
+
+	// only the top results are reranked
+	request.RerankTopK = 1
+	resp, err = SubcommandSemanticSearch(context.Background(), nil, request, indexPath, &buf)
+	if err != nil {
+		t.Fatalf("SubcommandSemanticSearch() returned unexpected error: %v", err)
+	}
+	if got := names(resp.Records); got != "a.md,b.md,c.md" {
+		t.Errorf("Expected the order to be kept past the reranked results, got %s", got)
+	}
+}
+
+func TestSubcommandSemanticSearch_Duplicates(t *testing.T) {
+	indexPath := t.TempDir()
+
+	schema := "the users table schema has columns for the id, the name and the email of each user"
+	indexMap := map[string]*pb.IndexListEntry{
+		"main/schema.sql":   {Name: "main/schema.sql", Chunks: []*pb.Chunk{{EntryName: "main/schema.sql", Text: schema}}},
+		"vendor/schema.sql": {Name: "vendor/schema.sql", Chunks: []*pb.Chunk{{EntryName: "vendor/schema.sql", Text: schema + " address"}}},
+		"login.md":          {Name: "login.md", Chunks: []*pb.Chunk{{EntryName: "login.md", Text: "users log in with a password"}}},
+	}
+	if err := writeIndex(path.Join(indexPath, indexFile), indexMap); err != nil {
+		t.Fatalf("Failed to write index: %v", err)
+	}
+
+	names := func(records []*pb.SearchRecord) string {
+		var names []string
+		for _, record := range records {
+			names = append(names, record.Name)
+		}
+		return strings.Join(names, ",")
+	}
+
+	var buf bytes.Buffer
+	request := &pb.SemanticSearchRequest{Query: "users schema", Mode: pb.SearchMode_LEXICAL}
+	resp, err := SubcommandSemanticSearch(context.Background(), nil, request, indexPath, &buf)
+	if err != nil {
+		t.Fatalf("SubcommandSemanticSearch() returned unexpected error: %v", err)
+	}
+	if got := names(resp.Records); got != "main/schema.sql,login.md" {
+		t.Errorf("Expected the vendored copy to be left out, got %s", got)
+	}
+	if !reflect.DeepEqual(resp.Records[0].Duplicates, []string{"vendor/schema.sql"}) {
+		t.Errorf("Expected the vendored copy among the duplicates, got %v", resp.Records[0].Duplicates)
+	}
+
+	request.IncludeDuplicates = true
+	resp, err = SubcommandSemanticSearch(context.Background(), nil, request, indexPath, &buf)
+	if err != nil {
+		t.Fatalf("SubcommandSemanticSearch() returned unexpected error: %v", err)
+	}
+	if got := names(resp.Records); got != "main/schema.sql,vendor/schema.sql,login.md" {
+		t.Errorf("Expected every chunk with duplicates included, got %s", got)
+	}
+
+	// diversifying moves the copy after the chunk that differs, even without embedded chunks
+	request.Diversify = true
+	request.MmrLambda = proto.Float32(0.3)
+	resp, err = SubcommandSemanticSearch(context.Background(), nil, request, indexPath, &buf)
+	if err != nil {
+		t.Fatalf("SubcommandSemanticSearch() returned unexpected error: %v", err)
+	}
+	if got := names(resp.Records); got != "main/schema.sql,login.md,vendor/schema.sql" {
+		t.Errorf("Expected the diversified order main/schema.sql,login.md,vendor/schema.sql, got %s", got)
+	}
+}
@@ -3,6 +3,7 @@ package subcommands
 import (
 	"bytes"
 	"context"
+	"errors"
 
 	db "accretional.com/semantifly/database"
 	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
@@ -31,17 +32,102 @@ func (s *Server) Add(ctx context.Context, req *pb.AddRequest) (*pb.AddResponse,
 	var buf bytes.Buffer
 	err := SubcommandAdd(s.dbContext, s.dbConn, req, s.serverIndexPath, &buf)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, statusError(err)
 	}
 	return &pb.AddResponse{ErrorMessage: buf.String()}, nil
 }
 
+func (s *Server) AddType(ctx context.Context, req *pb.AddTypeRequest) (*pb.AddTypeResponse, error) {
+
+	var buf bytes.Buffer
+	definition, err := SubcommandAddType(req, s.serverIndexPath, &buf)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &pb.AddTypeResponse{ErrorMessage: buf.String(), Type: definition}, nil
+}
+
+func (s *Server) DescribeData(ctx context.Context, req *pb.DescribeDataRequest) (*pb.DescribeDataResponse, error) {
+
+	var buf bytes.Buffer
+	description, err := SubcommandDescribeData(req, s.serverIndexPath, &buf)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &pb.DescribeDataResponse{ErrorMessage: buf.String(), Description: description}, nil
+}
+
+func (s *Server) DescribeType(ctx context.Context, req *pb.DescribeTypeRequest) (*pb.DescribeTypeResponse, error) {
+
+	var buf bytes.Buffer
+	description, err := SubcommandDescribeType(req, s.serverIndexPath, &buf)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &pb.DescribeTypeResponse{ErrorMessage: buf.String(), Description: description}, nil
+}
+
+func (s *Server) List(ctx context.Context, req *pb.ListRequest) (*pb.ListResponse, error) {
+
+	var buf bytes.Buffer
+	resp, err := SubcommandList(req, s.serverIndexPath, &buf)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp.ErrorMessage = buf.String()
+	return resp, nil
+}
+
+func (s *Server) Embed(ctx context.Context, req *pb.EmbedRequest) (*pb.EmbedResponse, error) {
+
+	var buf bytes.Buffer
+	resp, err := SubcommandEmbed(ctx, s.dbConn, req, s.serverIndexPath, &buf)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp.ErrorMessage = buf.String()
+	return resp, nil
+}
+
+func (s *Server) SemanticSearch(ctx context.Context, req *pb.SemanticSearchRequest) (*pb.SemanticSearchResponse, error) {
+
+	var buf bytes.Buffer
+	resp, err := SubcommandSemanticSearch(ctx, s.dbConn, req, s.serverIndexPath, &buf)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp.ErrorMessage = buf.String()
+	return resp, nil
+}
+
+func (s *Server) Context(ctx context.Context, req *pb.ContextRequest) (*pb.ContextResponse, error) {
+
+	var buf bytes.Buffer
+	resp, err := SubcommandContext(ctx, s.dbConn, req, s.serverIndexPath, &buf)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp.ErrorMessage = buf.String()
+	return resp, nil
+}
+
+func (s *Server) Generate(ctx context.Context, req *pb.GenerateRequest) (*pb.GenerateResponse, error) {
+
+	var buf bytes.Buffer
+	resp, err := SubcommandGenerate(ctx, s.dbConn, req, s.serverIndexPath, &buf, nil)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp.ErrorMessage = buf.String()
+	return resp, nil
+}
+
 func (s *Server) Delete(ctx context.Context, req *pb.DeleteRequest) (*pb.DeleteResponse, error) {
 
 	var buf bytes.Buffer
 	err := SubcommandDelete(s.dbContext, s.dbConn, req, s.serverIndexPath, &buf)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, statusError(err)
 	}
 	return &pb.DeleteResponse{ErrorMessage: buf.String()}, nil
 }
@@ -51,7 +137,7 @@ func (s *Server) Get(ctx context.Context, req *pb.GetRequest) (*pb.GetResponse,
 	var buf bytes.Buffer
 	content, contentMetadata, err := SubcommandGet(s.dbContext, s.dbConn, req, s.serverIndexPath, &buf)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, statusError(err)
 	}
 	return &pb.GetResponse{Content: &content, ReturnedMetadata: contentMetadata, ErrorMessage: buf.String()}, nil
 }
@@ -61,7 +147,7 @@ func (s *Server) Update(ctx context.Context, req *pb.UpdateRequest) (*pb.UpdateR
 	var buf bytes.Buffer
 	err := SubcommandUpdate(s.dbContext, s.dbConn, req, s.serverIndexPath, &buf)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, statusError(err)
 	}
 	return &pb.UpdateResponse{ErrorMessage: buf.String()}, nil
 }
@@ -71,7 +157,7 @@ func (s *Server) LexicalSearch(ctx context.Context, req *pb.LexicalSearchRequest
 
 	results, err := SubcommandLexicalSearch(req, s.serverIndexPath, &buf)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, statusError(err)
 	}
 
 	pbResults := make([]*pb.LexicalSearchResult, len(results))
@@ -79,8 +165,19 @@ func (s *Server) LexicalSearch(ctx context.Context, req *pb.LexicalSearchRequest
 		pbResults[i] = &pb.LexicalSearchResult{
 			Name:        result.FileName,
 			Occurrences: int32(result.Occurrence),
+			Sections:    result.Sections,
+			Chunks:      result.Chunks,
 		}
 	}
 
 	return &pb.LexicalSearchResponse{ErrorMessage: buf.String(), Results: pbResults}, nil
 }
+
+// statusError returns the status of an error of a subcommand: the code of a requestError, or Internal otherwise.
+func statusError(err error) error {
+	var requestErr *requestError
+	if errors.As(err, &requestErr) {
+		return status.Error(requestErr.code, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
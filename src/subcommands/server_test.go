@@ -3,16 +3,23 @@ package subcommands
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"accretional.com/semantifly/database"
+	"accretional.com/semantifly/gateway"
 	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 const testDir = "./test_semantifly"
@@ -244,3 +251,76 @@ func TestServerCommands(t *testing.T) {
 		t.Fatalf("Failed to add test file: %v", err)
 	}
 }
+
+func TestServer_StatusCodes(t *testing.T) {
+	indexPath := t.TempDir()
+	indexMap := map[string]*pb.IndexListEntry{
+		"guide.md": {Name: "guide.md", Content: "# Guide", ContentMetadata: &pb.ContentMetadata{URI: "guide.md", DataType: pb.DataType_MARKDOWN}},
+	}
+	if err := writeIndex(path.Join(indexPath, indexFile), indexMap); err != nil {
+		t.Fatalf("Failed to write index: %v", err)
+	}
+
+	server := SemantiflyNewServer(context.Background(), nil, indexPath)
+	ctx := context.Background()
+
+	if _, err := server.Get(ctx, &pb.GetRequest{Name: "missing.md"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for a missing entry, got %v", err)
+	}
+	if _, err := server.Get(ctx, &pb.GetRequest{Name: "guide.md", Section: "Install"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for a missing section, got %v", err)
+	}
+	if _, err := server.Update(ctx, &pb.UpdateRequest{Name: "missing.md", UpdatedMetadata: &pb.ContentMetadata{URI: "missing.md"}}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound when updating a missing entry, got %v", err)
+	}
+	if _, err := server.List(ctx, &pb.ListRequest{Sort: "bogus"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an unknown sort field, got %v", err)
+	}
+	if _, err := server.List(ctx, &pb.ListRequest{SourceType: "bogus"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an unknown source type, got %v", err)
+	}
+	if _, err := server.List(ctx, &pb.ListRequest{PageToken: "bogus"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an invalid page token, got %v", err)
+	}
+	if _, err := server.LexicalSearch(ctx, &pb.LexicalSearchRequest{SearchTerm: "guide"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a top_n of 0, got %v", err)
+	}
+	if _, err := server.SemanticSearch(ctx, &pb.SemanticSearchRequest{Query: " "}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an empty query, got %v", err)
+	}
+	if _, err := server.Context(ctx, &pb.ContextRequest{Question: "guide", Search: &pb.SemanticSearchRequest{MmrLambda: proto.Float32(2)}}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an invalid nested search, got %v", err)
+	}
+	if _, err := server.Generate(ctx, &pb.GenerateRequest{Question: "guide", Llm: "localhost:1", Context: &pb.ContextRequest{Search: &pb.SemanticSearchRequest{LexicalWeight: proto.Float32(-1)}}}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an invalid nested search, got %v", err)
+	}
+	if _, err := server.Get(ctx, &pb.GetRequest{Name: "guide.md"}); err != nil {
+		t.Errorf("Get returned unexpected error: %v", err)
+	}
+
+	// the gateway maps the codes to HTTP statuses
+	gw, err := gateway.New(server, nil)
+	if err != nil {
+		t.Fatalf("gateway.New() returned unexpected error: %v", err)
+	}
+	httpServer := httptest.NewServer(gw)
+	defer httpServer.Close()
+
+	resp, err := http.Get(httpServer.URL + "/v1/entries/missing.md")
+	if err != nil {
+		t.Fatalf("GET /v1/entries/missing.md failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 for a missing entry, got %d", resp.StatusCode)
+	}
+
+	resp, err = http.Get(httpServer.URL + "/v1/entries?sort=bogus")
+	if err != nil {
+		t.Fatalf("GET /v1/entries failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown sort field, got %d", resp.StatusCode)
+	}
+}
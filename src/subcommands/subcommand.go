@@ -4,20 +4,34 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	db "accretional.com/semantifly/database"
+	"accretional.com/semantifly/gateway"
 	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+	"accretional.com/semantifly/tokens"
 	"github.com/jackc/pgx/v5"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 var defaultIndexPath = path.Join(os.ExpandEnv("$HOME/.semantifly"), "default")
 
+// Timeouts of the REST API and of MCP over HTTP. Requests have no deadline as generating an answer may take minutes.
+const (
+	httpReadHeaderTimeout = 10 * time.Second
+	httpIdleTimeout       = 2 * time.Minute
+)
+
 type SubcommandInfo struct {
 	Description string
 	Execute     func(context.Context, *db.PgxIface, []string)
@@ -25,7 +39,7 @@ type SubcommandInfo struct {
 
 var subcommandDict = map[string]SubcommandInfo{
 	"add": {
-		Description: "Add new data to the index",
+		Description: "Add new data to the index, or a new type with 'add type <name> <file.proto>'",
 		Execute:     executeAdd,
 	},
 	"delete": {
@@ -40,10 +54,42 @@ var subcommandDict = map[string]SubcommandInfo{
 		Description: "Update existing data in the index",
 		Execute:     executeUpdate,
 	},
+	"describe": {
+		Description: "Describe an entry with 'describe data <name>' or a type with 'describe type <type>'",
+		Execute:     executeDescribe,
+	},
+	"list": {
+		Description: "List data in the index, eg. 'list data --type markdown --sort refreshed'",
+		Execute:     executeList,
+	},
+	"embed": {
+		Description: "Compute vector embeddings of chunks, eg. 'embed --all' or 'embed type <type>'",
+		Execute:     executeEmbed,
+	},
+	"context": {
+		Description: "Assemble the chunks most related to a question into a context block for a prompt, eg. 'context \"how are users stored?\"'",
+		Execute:     executeContext,
+	},
+	"generate": {
+		Description: "Answer a question with a language model given the chunks most related to it, eg. 'generate \"sql to find the first user\" --llm localhost:8080'",
+		Execute:     executeGenerate,
+	},
+	"query": {
+		Description: "Search (semantically) for the chunks most related to a query, eg. 'query \"users database schema\"'",
+		Execute:     executeQuery,
+	},
+	"template": {
+		Description: "Manage prompt templates with 'template list', 'template show <name>' or 'template add <name> <file.json>'",
+		Execute:     executeTemplate,
+	},
 	"search": {
 		Description: "Search (lexically) for a term in the index",
 		Execute:     executeSearch,
 	},
+	"mcp": {
+		Description: "Serve the index to coding assistants over the Model Context Protocol, on stdio or with --http <address>",
+		Execute:     executeMCP,
+	},
 	"start-server": {
 		Description: "Start GRPC server",
 		Execute:     executeStartServer,
@@ -173,10 +219,17 @@ func inferSourceType(uris []string) (string, error) {
 }
 
 func executeAdd(ctx context.Context, conn *db.PgxIface, args []string) {
+	if len(args) > 0 && args[0] == "type" {
+		executeAddType(args[1:])
+		return
+	}
+
 	cmd := flag.NewFlagSet("add", flag.ExitOnError)
-	dataType := cmd.String("type", "text", "The type of the input data")
+	dataType := cmd.String("type", "auto", "The type of the input data, or auto to detect it from the file name and content")
 	sourceType := cmd.String("source-type", "", "How to access the content")
 	makeLocalCopy := cmd.Bool("copy", false, "Whether to copy and use the file as it is now, or dynamically access it")
+	extractOptions := cmd.String("extract-options", "", "Comma separated key=value options for the data type's extractor, eg. outputs=true")
+	dataSource := cmd.String("datasource", "", "Label of the data source the data belongs to, eg. aws_prod")
 	indexPath := cmd.String("index-path", defaultIndexPath, "Path to the index file")
 
 	flags, nonFlags, err := parseArgs(args, cmd)
@@ -201,9 +254,16 @@ func executeAdd(ctx context.Context, conn *db.PgxIface, args []string) {
 		*sourceType = sourceTypeStr
 	}
 
-	dataTypeEnum, err := parseDataType(*dataType)
-	if err != nil {
-		printCmdErr(fmt.Sprintf("Error in parsing DataType: %v\n", err))
+	detectDataType := strings.EqualFold(*dataType, "auto")
+	dataTypeEnum := pb.DataType_TEXT
+	typeName := ""
+	if !detectDataType {
+		dataTypeEnum, err = parseDataType(*dataType)
+		if err != nil {
+			// names that are not built-in data types refer to types registered with "add type"
+			dataTypeEnum = pb.DataType_CUSTOM
+			typeName = *dataType
+		}
 	}
 
 	sourceTypeEnum, err := parseSourceType(*sourceType)
@@ -211,6 +271,12 @@ func executeAdd(ctx context.Context, conn *db.PgxIface, args []string) {
 		printCmdErr(fmt.Sprintf("Error in parsing SourceType: %v\n", err))
 	}
 
+	extractOptionsMap, err := parseExtractOptions(*extractOptions)
+	if err != nil {
+		printCmdErr(fmt.Sprintf("Error in parsing extract options: %v\n", err))
+		return
+	}
+
 	dataUri := cmd.Args()[0]
 
 	if *sourceType == "local_file" {
@@ -219,11 +285,15 @@ func executeAdd(ctx context.Context, conn *db.PgxIface, args []string) {
 
 	addArgs := &pb.AddRequest{
 		AddedMetadata: &pb.ContentMetadata{
-			URI:        dataUri,
-			DataType:   dataTypeEnum,
-			SourceType: sourceTypeEnum,
+			URI:            dataUri,
+			DataType:       dataTypeEnum,
+			SourceType:     sourceTypeEnum,
+			ExtractOptions: extractOptionsMap,
+			TypeName:       typeName,
+			DataSource:     *dataSource,
 		},
-		MakeCopy: *makeLocalCopy,
+		MakeCopy:       *makeLocalCopy,
+		DetectDataType: detectDataType,
 	}
 
 	err = SubcommandAdd(ctx, conn, addArgs, *indexPath, os.Stdout)
@@ -233,6 +303,210 @@ func executeAdd(ctx context.Context, conn *db.PgxIface, args []string) {
 	}
 }
 
+func executeAddType(args []string) {
+	cmd := flag.NewFlagSet("add type", flag.ExitOnError)
+	messageName := cmd.String("message", "", "Full name of the message backing the type, if the file defines several")
+	importPaths := cmd.String("import-path", "", "Comma separated directories to resolve imports of the .proto file from")
+	indexPath := cmd.String("index-path", defaultIndexPath, "Path to the index file")
+
+	flags, nonFlags, err := parseArgs(args, cmd)
+	if err != nil {
+		printCmdErr(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	reorderedArgs := append(flags, nonFlags...)
+	cmd.Parse(reorderedArgs)
+
+	if len(nonFlags) != 2 {
+		printCmdErr("Add type subcommand requires a type name and a .proto file, eg. 'add type SuperCerealized supercerealized.proto'")
+		return
+	}
+
+	addTypeArgs := &pb.AddTypeRequest{
+		Name:        cmd.Args()[0],
+		ProtoFile:   convertToAbsPath(cmd.Args()[1]),
+		MessageName: *messageName,
+	}
+	if *importPaths != "" {
+		for _, p := range strings.Split(*importPaths, ",") {
+			addTypeArgs.ImportPaths = append(addTypeArgs.ImportPaths, convertToAbsPath(p))
+		}
+	}
+
+	if _, err := SubcommandAddType(addTypeArgs, *indexPath, os.Stdout); err != nil {
+		fmt.Printf("Error occurred during add type subcommand: %v\n", err)
+		return
+	}
+}
+
+func executeDescribe(ctx context.Context, conn *db.PgxIface, args []string) {
+	cmd := flag.NewFlagSet("describe", flag.ExitOnError)
+	jsonOutput := cmd.Bool("json", false, "Print the description as JSON")
+	topTerms := cmd.Int("top-terms", defaultTopTerms, "Number of most frequent terms to show for data")
+	indexPath := cmd.String("index-path", defaultIndexPath, "Path to the index file")
+
+	flags, nonFlags, err := parseArgs(args, cmd)
+	if err != nil {
+		printCmdErr(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	reorderedArgs := append(flags, nonFlags...)
+	cmd.Parse(reorderedArgs)
+
+	if len(nonFlags) != 2 || (nonFlags[0] != "data" && nonFlags[0] != "type") {
+		printCmdErr("Describe subcommand requires 'data <name>' or 'type <type>'.")
+		return
+	}
+
+	var description proto.Message
+	switch cmd.Args()[0] {
+	case "data":
+		name := cmd.Args()[1]
+		if sourceType, _ := inferSourceType([]string{name}); sourceType == "local_file" {
+			name = convertToAbsPath(name)
+		}
+		description, err = SubcommandDescribeData(&pb.DescribeDataRequest{Name: name, TopTerms: int32(*topTerms)}, *indexPath, os.Stdout)
+
+	case "type":
+		description, err = SubcommandDescribeType(&pb.DescribeTypeRequest{Name: cmd.Args()[1]}, *indexPath, os.Stdout)
+	}
+	if err != nil {
+		fmt.Printf("Error occurred during describe subcommand: %v\n", err)
+		return
+	}
+
+	if *jsonOutput {
+		data, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(description)
+		if err != nil {
+			fmt.Printf("Failed to marshal description: %v\n", err)
+			return
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	switch d := description.(type) {
+	case *pb.DataDescription:
+		printDataDescription(os.Stdout, d)
+	case *pb.TypeDescription:
+		printTypeDescription(os.Stdout, d)
+	}
+}
+
+func executeList(ctx context.Context, conn *db.PgxIface, args []string) {
+	cmd := flag.NewFlagSet("list", flag.ExitOnError)
+	dataType := cmd.String("type", "", "Only list data of this type")
+	dataSource := cmd.String("datasource", "", "Only list data from this data source")
+	sourceType := cmd.String("source-type", "", "Only list data with this source type, eg. webpage")
+	staleSince := cmd.String("stale-since", "", "Only list data not refreshed for this long, eg. 7d or 12h")
+	sortBy := cmd.String("sort", "name", "Sort by name, added, refreshed or size")
+	descending := cmd.Bool("desc", false, "Sort in descending order")
+	format := cmd.String("format", "table", "Output format: table, jsonl or names")
+	indexPath := cmd.String("index-path", defaultIndexPath, "Path to the index file")
+
+	flags, nonFlags, err := parseArgs(args, cmd)
+	if err != nil {
+		printCmdErr(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	reorderedArgs := append(flags, nonFlags...)
+	cmd.Parse(reorderedArgs)
+
+	if len(nonFlags) > 1 || (len(nonFlags) == 1 && nonFlags[0] != "data") {
+		printCmdErr("List subcommand takes no args other than 'data'.")
+		return
+	}
+
+	listArgs := &pb.ListRequest{
+		DataType:   *dataType,
+		DataSource: *dataSource,
+		SourceType: *sourceType,
+		Sort:       *sortBy,
+		Descending: *descending,
+	}
+
+	if *staleSince != "" {
+		age, err := parseAge(*staleSince)
+		if err != nil {
+			printCmdErr(fmt.Sprintf("Error in parsing --stale-since: %v", err))
+			return
+		}
+		listArgs.StaleSince = durationpb.New(age)
+	}
+
+	resp, err := SubcommandList(listArgs, *indexPath, os.Stdout)
+	if err != nil {
+		fmt.Printf("Error occurred during list subcommand: %v\n", err)
+		return
+	}
+
+	if err := printList(os.Stdout, resp.Entries, *format); err != nil {
+		fmt.Printf("Error occurred during list subcommand: %v\n", err)
+	}
+}
+
+func executeEmbed(ctx context.Context, conn *db.PgxIface, args []string) {
+	cmd := flag.NewFlagSet("embed", flag.ExitOnError)
+	all := cmd.Bool("all", false, "Embed all data in the index")
+	force := cmd.Bool("force", false, "Embed chunks again even if their content and the model are unchanged")
+	workers := cmd.Int("workers", defaultEmbedWorkers, "Number of concurrent requests to the embedding provider")
+	indexSource := cmd.String("index-source", "index_file", "Where to store vectors: index_file or database")
+	indexPath := cmd.String("index-path", defaultIndexPath, "Path to the index file")
+
+	flags, nonFlags, err := parseArgs(args, cmd)
+	if err != nil {
+		printCmdErr(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	reorderedArgs := append(flags, nonFlags...)
+	cmd.Parse(reorderedArgs)
+
+	embedArgs := &pb.EmbedRequest{
+		All:     *all,
+		Force:   *force,
+		Workers: int32(*workers),
+	}
+
+	embedArgs.IndexSource, err = parseIndexSource(*indexSource)
+	if err != nil {
+		printCmdErr(fmt.Sprintf("Error in parsing --index-source: %v", err))
+		return
+	}
+
+	switch {
+	case *all:
+		if len(nonFlags) > 0 {
+			printCmdErr("Embed subcommand takes no args with --all.")
+			return
+		}
+	case len(nonFlags) > 0 && nonFlags[0] == "type":
+		if len(nonFlags) != 2 {
+			printCmdErr("Embed subcommand requires exactly one type with 'type <type>'.")
+			return
+		}
+		embedArgs.DataType = nonFlags[1]
+	case len(nonFlags) > 0:
+		for _, name := range nonFlags {
+			if sourceType, _ := inferSourceType([]string{name}); sourceType == "local_file" {
+				name = convertToAbsPath(name)
+			}
+			embedArgs.Names = append(embedArgs.Names, name)
+		}
+	default:
+		printCmdErr("Embed subcommand requires --all, 'type <type>' or names of data.")
+		return
+	}
+
+	if _, err := SubcommandEmbed(ctx, conn, embedArgs, *indexPath, os.Stdout); err != nil {
+		fmt.Printf("Error occurred during embed subcommand: %v\n", err)
+		return
+	}
+}
+
 func executeDelete(ctx context.Context, conn *db.PgxIface, args []string) {
 	cmd := flag.NewFlagSet("delete", flag.ExitOnError)
 	deleteLocalCopy := cmd.Bool("copy", false, "Whether to delete the copy made")
@@ -277,6 +551,9 @@ func executeGet(ctx context.Context, conn *db.PgxIface, args []string) {
 	cmd := flag.NewFlagSet("get", flag.ExitOnError)
 	indexPath := cmd.String("index-path", defaultIndexPath, "Path to the index file")
 	indexSource := cmd.String("index-source", "index_file", "Type of index list source: file or database")
+	section := cmd.String("section", "", "Path of the section to retrieve, eg. 'Intended Usage > RAG'")
+	text := cmd.Bool("text", false, "Print the text extracted according to the data type rather than the raw content")
+	chunk := cmd.Int("chunk", -1, "Ordinal of the chunk to retrieve, from 0")
 
 	flags, nonFlags, err := parseArgs(args, cmd)
 	if err != nil {
@@ -306,22 +583,32 @@ func executeGet(ctx context.Context, conn *db.PgxIface, args []string) {
 	getArgs := &pb.GetRequest{
 		Name:        dataUri,
 		IndexSource: indexSourceEnum,
+		Section:     *section,
+		Text:        *text,
+	}
+	if *chunk >= 0 {
+		getArgs.Chunk = proto.Int32(int32(*chunk))
 	}
 
-	resp, _, err := SubcommandGet(ctx, conn, getArgs, *indexPath, os.Stdout)
+	resp, metadata, err := SubcommandGet(ctx, conn, getArgs, *indexPath, os.Stdout)
 	if err != nil {
 		fmt.Printf("Error occurred during get subcommand: %v\n", err)
 		return
 	}
 
+	if metadata.GetDetectedBy() != "" {
+		fmt.Printf("Data type: %s (detected by %s)\n", metadata.GetDataType(), metadata.GetDetectedBy())
+	}
+
 	fmt.Println(resp)
 }
 
 func executeUpdate(ctx context.Context, conn *db.PgxIface, args []string) {
 	cmd := flag.NewFlagSet("update", flag.ExitOnError)
-	dataType := cmd.String("type", "text", "The type of the input data")
+	dataType := cmd.String("type", "", "The type of the updated data, auto to detect it from the file name and content, or the current type of the entry if empty")
 	sourceType := cmd.String("source-type", "", "How to access the content")
 	makeLocalCopy := cmd.Bool("copy", false, "Whether to copy and use the file as it is now, or dynamically access it")
+	extractOptions := cmd.String("extract-options", "", "Comma separated key=value options for the data type's extractor, eg. outputs=true")
 	indexPath := cmd.String("index-path", defaultIndexPath, "Path to the index file")
 
 	flags, nonFlags, err := parseArgs(args, cmd)
@@ -346,9 +633,17 @@ func executeUpdate(ctx context.Context, conn *db.PgxIface, args []string) {
 		*sourceType = sourceTypeStr
 	}
 
-	dataTypeEnum, err := parseDataType(*dataType)
-	if err != nil {
-		printCmdErr(fmt.Sprintf("Error in parsing DataType: %v\n", err))
+	keepDataType := *dataType == ""
+	detectDataType := strings.EqualFold(*dataType, "auto")
+	dataTypeEnum := pb.DataType_TEXT
+	typeName := ""
+	if !keepDataType && !detectDataType {
+		dataTypeEnum, err = parseDataType(*dataType)
+		if err != nil {
+			// names that are not built-in data types refer to types registered with "add type"
+			dataTypeEnum = pb.DataType_CUSTOM
+			typeName = *dataType
+		}
 	}
 
 	sourceTypeEnum, err := parseSourceType(*sourceType)
@@ -356,6 +651,12 @@ func executeUpdate(ctx context.Context, conn *db.PgxIface, args []string) {
 		printCmdErr(fmt.Sprintf("Error in parsing SourceType: %v\n", err))
 	}
 
+	extractOptionsMap, err := parseExtractOptions(*extractOptions)
+	if err != nil {
+		printCmdErr(fmt.Sprintf("Error in parsing extract options: %v\n", err))
+		return
+	}
+
 	originalDataUri := cmd.Args()[0]
 	updatedDataUri := cmd.Args()[1]
 
@@ -367,11 +668,15 @@ func executeUpdate(ctx context.Context, conn *db.PgxIface, args []string) {
 	updateArgs := &pb.UpdateRequest{
 		Name: originalDataUri,
 		UpdatedMetadata: &pb.ContentMetadata{
-			URI:        updatedDataUri,
-			DataType:   dataTypeEnum,
-			SourceType: sourceTypeEnum,
+			URI:            updatedDataUri,
+			DataType:       dataTypeEnum,
+			SourceType:     sourceTypeEnum,
+			ExtractOptions: extractOptionsMap,
+			TypeName:       typeName,
 		},
-		UpdateCopy: *makeLocalCopy,
+		UpdateCopy:     *makeLocalCopy,
+		DetectDataType: detectDataType,
+		KeepDataType:   keepDataType,
 	}
 
 	err = SubcommandUpdate(ctx, conn, updateArgs, *indexPath, os.Stdout)
@@ -414,10 +719,361 @@ func executeSearch(ctx context.Context, conn *db.PgxIface, args []string) {
 	PrintSearchResults(results, os.Stdout)
 }
 
+func executeContext(ctx context.Context, conn *db.PgxIface, args []string) {
+	cmd := flag.NewFlagSet("context", flag.ExitOnError)
+	maxTokens := cmd.Int("max_tokens", defaultContextTokens, "Maximum number of tokens of the context")
+	tokenizer := cmd.String("tokenizer", "approx", "How tokens are counted: "+strings.Join(tokens.Names(), ", "))
+	maxRecords := cmd.Int("max_records", defaultContextRecords, "Maximum number of chunks to retrieve")
+	mode := cmd.String("mode", "semantic", "How to retrieve chunks: lexical, semantic or hybrid")
+	rerank := cmd.Bool("rerank", false, "Rerank the retrieved chunks with the reranker of the config")
+	templateName := cmd.String("template", "", "Prompt template rendering the chunks, see 'template list'; the default one if empty")
+	out := cmd.String("out", "", "File to write the context to instead of stdout")
+	indexSource := cmd.String("index-source", "index_file", "Where to search vectors: index_file or database")
+	indexPath := cmd.String("index-path", defaultIndexPath, "Path to the index file")
+
+	flags, nonFlags, err := parseArgs(args, cmd)
+	if err != nil {
+		printCmdErr(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	reorderedArgs := append(flags, nonFlags...)
+	cmd.Parse(reorderedArgs)
+
+	if len(nonFlags) != 1 {
+		printCmdErr("Context subcommand requires exactly one arg, the quoted question.")
+		return
+	}
+
+	contextArgs := &pb.ContextRequest{
+		Question:  cmd.Args()[0],
+		MaxTokens: int32(*maxTokens),
+		Tokenizer: *tokenizer,
+		Template:  *templateName,
+		Search: &pb.SemanticSearchRequest{
+			MaxRecords: int32(*maxRecords),
+			Rerank:     *rerank,
+		},
+	}
+
+	contextArgs.Search.Mode, err = parseSearchMode(*mode)
+	if err != nil {
+		printCmdErr(fmt.Sprintf("Error in parsing --mode: %v", err))
+		return
+	}
+
+	contextArgs.Search.IndexSource, err = parseIndexSource(*indexSource)
+	if err != nil {
+		printCmdErr(fmt.Sprintf("Error in parsing --index-source: %v", err))
+		return
+	}
+
+	resp, err := SubcommandContext(ctx, conn, contextArgs, *indexPath, os.Stdout)
+	if err != nil {
+		fmt.Printf("Error occurred during context subcommand: %v\n", err)
+		return
+	}
+
+	if *out == "" {
+		fmt.Print(resp.Context)
+		return
+	}
+
+	if err := os.WriteFile(*out, []byte(resp.Context), 0644); err != nil {
+		fmt.Printf("Failed to write %s: %v\n", *out, err)
+		return
+	}
+	fmt.Printf("Wrote %d chunks and %d tokens to %s\n", len(resp.Citations), resp.TokenCount, *out)
+}
+
+func executeGenerate(ctx context.Context, conn *db.PgxIface, args []string) {
+	cmd := flag.NewFlagSet("generate", flag.ExitOnError)
+	llmAddress := cmd.String("llm", "", "Address or base URL of an OpenAI compatible chat completions API; the config's if empty")
+	model := cmd.String("model", "", "Model id sent to the API; the config's if empty")
+	maxRecords := cmd.Int("max_records", defaultContextRecords, "Maximum number of chunks to retrieve")
+	maxSize := cmd.String("max_size", "", "Maximum total size of the retrieved chunks, eg. 10KB; unlimited if empty")
+	maxTokens := cmd.Int("max_tokens", defaultContextTokens, "Maximum number of tokens of the context")
+	tokenizer := cmd.String("tokenizer", "approx", "How tokens are counted: "+strings.Join(tokens.Names(), ", "))
+	mode := cmd.String("mode", "semantic", "How to retrieve chunks: lexical, semantic or hybrid")
+	rerank := cmd.Bool("rerank", false, "Rerank the retrieved chunks with the reranker of the config")
+	templateName := cmd.String("template", "", "Prompt template of the question and its context, see 'template list'; the default one if empty")
+	citations := cmd.Bool("citations", true, "Append the sources given to the model to the answer")
+	out := cmd.String("out", "", "File to write the answer to instead of stdout; citations are still printed to stdout")
+	indexSource := cmd.String("index-source", "index_file", "Where to search vectors: index_file or database")
+	indexPath := cmd.String("index-path", defaultIndexPath, "Path to the index file")
+
+	flags, nonFlags, err := parseArgs(args, cmd)
+	if err != nil {
+		printCmdErr(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	reorderedArgs := append(flags, nonFlags...)
+	cmd.Parse(reorderedArgs)
+
+	if len(nonFlags) != 1 {
+		printCmdErr("Generate subcommand requires exactly one arg, the quoted question.")
+		return
+	}
+
+	generateArgs := &pb.GenerateRequest{
+		Question: cmd.Args()[0],
+		Llm:      *llmAddress,
+		Model:    *model,
+		Template: *templateName,
+		Context: &pb.ContextRequest{
+			MaxTokens: int32(*maxTokens),
+			Tokenizer: *tokenizer,
+			Search: &pb.SemanticSearchRequest{
+				MaxRecords: int32(*maxRecords),
+				Rerank:     *rerank,
+			},
+		},
+	}
+	search := generateArgs.Context.Search
+
+	if *maxSize != "" {
+		search.MaxSize, err = parseSize(*maxSize)
+		if err != nil {
+			printCmdErr(fmt.Sprintf("Error in parsing --max_size: %v", err))
+			return
+		}
+	}
+
+	search.Mode, err = parseSearchMode(*mode)
+	if err != nil {
+		printCmdErr(fmt.Sprintf("Error in parsing --mode: %v", err))
+		return
+	}
+
+	search.IndexSource, err = parseIndexSource(*indexSource)
+	if err != nil {
+		printCmdErr(fmt.Sprintf("Error in parsing --index-source: %v", err))
+		return
+	}
+
+	// the answer is streamed to stdout, or written to --out once it is complete so that a failure leaves no file
+	var stream io.Writer = os.Stdout
+	if *out != "" {
+		stream = nil
+	}
+
+	resp, err := SubcommandGenerate(ctx, conn, generateArgs, *indexPath, os.Stdout, stream)
+	if err != nil {
+		fmt.Printf("Error occurred during generate subcommand: %v\n", err)
+		return
+	}
+
+	text := resp.Text
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+		if *out == "" {
+			fmt.Println()
+		}
+	}
+
+	if *out != "" {
+		if err := os.WriteFile(*out, []byte(text), 0644); err != nil {
+			fmt.Printf("Failed to write to %s: %v\n", *out, err)
+			return
+		}
+		fmt.Printf("Wrote the answer of %s to %s\n", resp.Model, *out)
+	}
+
+	if *citations && len(resp.Citations) > 0 {
+		fmt.Printf("\n%s", formatSources(resp.Citations))
+	}
+}
+
+func executeTemplate(ctx context.Context, conn *db.PgxIface, args []string) {
+	cmd := flag.NewFlagSet("template", flag.ExitOnError)
+	replace := cmd.Bool("replace", false, "Replace the template with the same name when adding one")
+	indexPath := cmd.String("index-path", defaultIndexPath, "Path to the index file")
+
+	flags, nonFlags, err := parseArgs(args, cmd)
+	if err != nil {
+		printCmdErr(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	reorderedArgs := append(flags, nonFlags...)
+	cmd.Parse(reorderedArgs)
+
+	switch {
+	case len(nonFlags) == 1 && nonFlags[0] == "list":
+		_, err = SubcommandListTemplates(*indexPath, os.Stdout)
+
+	case len(nonFlags) == 2 && nonFlags[0] == "show":
+		_, err = SubcommandShowTemplate(cmd.Args()[1], *indexPath, os.Stdout)
+
+	case len(nonFlags) == 3 && nonFlags[0] == "add":
+		data, readErr := os.ReadFile(cmd.Args()[2])
+		if readErr != nil {
+			fmt.Printf("Failed to read %s: %v\n", cmd.Args()[2], readErr)
+			return
+		}
+
+		definition := &pb.PromptTemplate{}
+		if err := protojson.Unmarshal(data, definition); err != nil {
+			fmt.Printf("Failed to parse %s: %v\n", cmd.Args()[2], err)
+			return
+		}
+		definition.Name = cmd.Args()[1]
+
+		_, err = SubcommandAddTemplate(definition, *replace, *indexPath, os.Stdout)
+
+	default:
+		printCmdErr("Template subcommand requires 'list', 'show <name>' or 'add <name> <file.json>'.")
+		return
+	}
+	if err != nil {
+		fmt.Printf("Error occurred during template subcommand: %v\n", err)
+		return
+	}
+}
+
+func executeQuery(ctx context.Context, conn *db.PgxIface, args []string) {
+	cmd := flag.NewFlagSet("query", flag.ExitOnError)
+	maxRecords := cmd.Int("max_records", defaultMaxRecords, "Maximum number of records to return")
+	maxSize := cmd.String("max_size", "", "Maximum total size of the records, eg. 10KB; unlimited if empty")
+	out := cmd.String("out", "", "File to write the records to instead of stdout")
+	indexSource := cmd.String("index-source", "index_file", "Where to search vectors: index_file or database")
+	mode := cmd.String("mode", "semantic", "How to retrieve chunks: lexical, semantic or hybrid")
+	fusion := cmd.String("fusion", "rrf", "How to combine the rankings of a hybrid search: rrf or weighted")
+	lexicalWeight := cmd.Float64("lexical_weight", 1, "Weight of the lexical ranking in a hybrid search")
+	semanticWeight := cmd.Float64("semantic_weight", 1, "Weight of the semantic ranking in a hybrid search")
+	rerank := cmd.Bool("rerank", false, "Rerank the results with the reranker of the config")
+	rerankTopK := cmd.Int("rerank_top_k", 0, "Number of results to rerank; the config's, or 50, if 0")
+	includeDuplicates := cmd.Bool("include_duplicates", false, "Return every near-duplicate chunk rather than one of each group")
+	diversify := cmd.Bool("diversify", false, "Diversify the results with maximal marginal relevance")
+	mmrLambda := cmd.Float64("mmr_lambda", 0.5, "Trade-off between relevance (1) and diversity (0) when diversifying")
+	indexPath := cmd.String("index-path", defaultIndexPath, "Path to the index file")
+
+	flags, nonFlags, err := parseArgs(args, cmd)
+	if err != nil {
+		printCmdErr(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	reorderedArgs := append(flags, nonFlags...)
+	cmd.Parse(reorderedArgs)
+
+	if len(nonFlags) != 1 {
+		printCmdErr("Query subcommand requires exactly one arg, the quoted query.")
+		return
+	}
+
+	queryArgs := &pb.SemanticSearchRequest{
+		Query:             cmd.Args()[0],
+		MaxRecords:        int32(*maxRecords),
+		LexicalWeight:     proto.Float32(float32(*lexicalWeight)),
+		SemanticWeight:    proto.Float32(float32(*semanticWeight)),
+		Rerank:            *rerank,
+		RerankTopK:        int32(*rerankTopK),
+		IncludeDuplicates: *includeDuplicates,
+		Diversify:         *diversify,
+		MmrLambda:         proto.Float32(float32(*mmrLambda)),
+	}
+
+	if *maxSize != "" {
+		queryArgs.MaxSize, err = parseSize(*maxSize)
+		if err != nil {
+			printCmdErr(fmt.Sprintf("Error in parsing --max_size: %v", err))
+			return
+		}
+	}
+
+	queryArgs.IndexSource, err = parseIndexSource(*indexSource)
+	if err != nil {
+		printCmdErr(fmt.Sprintf("Error in parsing --index-source: %v", err))
+		return
+	}
+
+	queryArgs.Mode, err = parseSearchMode(*mode)
+	if err != nil {
+		printCmdErr(fmt.Sprintf("Error in parsing --mode: %v", err))
+		return
+	}
+
+	queryArgs.Fusion, err = parseFusion(*fusion)
+	if err != nil {
+		printCmdErr(fmt.Sprintf("Error in parsing --fusion: %v", err))
+		return
+	}
+
+	resp, err := SubcommandSemanticSearch(ctx, conn, queryArgs, *indexPath, os.Stdout)
+	if err != nil {
+		fmt.Printf("Error occurred during query subcommand: %v\n", err)
+		return
+	}
+
+	if *out == "" {
+		printSearchRecords(os.Stdout, resp.Records)
+		return
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		fmt.Printf("Failed to create %s: %v\n", *out, err)
+		return
+	}
+	defer f.Close()
+
+	printSearchRecords(f, resp.Records)
+	fmt.Printf("Wrote %d records to %s\n", len(resp.Records), *out)
+}
+
+func executeMCP(ctx context.Context, conn *db.PgxIface, args []string) {
+	cmd := flag.NewFlagSet("mcp", flag.ExitOnError)
+	httpAddress := cmd.String("http", "", "Address to serve streamable HTTP on at /mcp, eg. localhost:8090; stdio if empty. Keep the host localhost unless the index is meant to be exposed")
+	indexPath := cmd.String("index-path", defaultIndexPath, "Path to the index file")
+
+	flags, nonFlags, err := parseArgs(args, cmd)
+	if err != nil {
+		printCmdErr(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	reorderedArgs := append(flags, nonFlags...)
+	cmd.Parse(reorderedArgs)
+
+	if len(nonFlags) != 0 {
+		printCmdErr("MCP subcommand takes no args.")
+		return
+	}
+
+	server := newMCPServer(conn, *indexPath)
+
+	// stdout carries the protocol, so that logs go to stderr
+	if *httpAddress == "" {
+		if err := server.ServeStdio(ctx, os.Stdin, os.Stdout); err != nil {
+			log.Fatalf("failed to serve: %v", err)
+		}
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/mcp", server)
+	httpServer := &http.Server{
+		Addr:              *httpAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: httpReadHeaderTimeout,
+		IdleTimeout:       httpIdleTimeout,
+	}
+	log.Printf("MCP server listening at http://%s/mcp", *httpAddress)
+	log.Printf("using index path: %v", *indexPath)
+	if err := httpServer.ListenAndServe(); err != nil {
+		log.Fatalf("failed to serve: %v", err)
+	}
+}
+
 func executeStartServer(ctx context.Context, conn *db.PgxIface, args []string) {
 	cmd := flag.NewFlagSet("start-server", flag.ExitOnError)
 	serverIndexPath := cmd.String("index-path", defaultIndexPath, "Path to the server index")
 	port := cmd.String("port", "50051", "Port for the server")
+	httpPort := cmd.String("http-port", "", "Port for the REST API of JSON requests, eg. 8080; disabled if empty")
+	httpHost := cmd.String("http-host", "localhost", "Host the REST API listens on; all interfaces if empty")
+	corsOrigins := cmd.String("cors-origins", "", "Comma separated origins of the pages allowed to call the REST API, eg. http://localhost:3000, or *")
 
 	flags, nonFlags, err := parseArgs(args, cmd)
 	if err != nil {
@@ -433,8 +1089,37 @@ func executeStartServer(ctx context.Context, conn *db.PgxIface, args []string) {
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
+	server := SemantiflyNewServer(ctx, conn, *serverIndexPath)
 	s := grpc.NewServer()
-	pb.RegisterSemantiflyServer(s, SemantiflyNewServer(ctx, conn, *serverIndexPath))
+	pb.RegisterSemantiflyServer(s, server)
+
+	if *httpPort != "" {
+		var origins []string
+		if *corsOrigins != "" {
+			origins = strings.Split(*corsOrigins, ",")
+		}
+		gw, err := gateway.New(server, origins)
+		if err != nil {
+			log.Fatalf("failed to create the HTTP gateway: %v", err)
+		}
+
+		httpLis, err := net.Listen("tcp", net.JoinHostPort(*httpHost, *httpPort))
+		if err != nil {
+			log.Fatalf("failed to listen: %v", err)
+		}
+		httpServer := &http.Server{
+			Handler:           gw,
+			ReadHeaderTimeout: httpReadHeaderTimeout,
+			IdleTimeout:       httpIdleTimeout,
+		}
+		log.Printf("HTTP gateway listening at %v, described at %s", httpLis.Addr(), gateway.OpenAPIPath)
+		go func() {
+			if err := httpServer.Serve(httpLis); err != nil {
+				log.Fatalf("failed to serve HTTP: %v", err)
+			}
+		}()
+	}
+
 	log.Printf("server listening at %v", lis.Addr())
 	log.Printf("using index path: %v", *serverIndexPath)
 	if err := s.Serve(lis); err != nil {
@@ -0,0 +1,188 @@
+package subcommands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"text/tabwriter"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"accretional.com/semantifly/prompt"
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+const templatesFile = "templates.list"
+
+var templateNameRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+// SubcommandAddTemplate adds a prompt template to the index, or replaces the one with the same name if replace is
+// set. Templates are parsed and executed with sample data first, so that broken templates are never stored.
+func SubcommandAddTemplate(definition *pb.PromptTemplate, replace bool, indexPath string, w io.Writer) (*pb.PromptTemplate, error) {
+	if !templateNameRegex.MatchString(definition.Name) {
+		return nil, invalidArgumentf("invalid template name %s, expected a letter followed by letters, digits, underscores or hyphens", definition.Name)
+	}
+
+	if prompt.Builtin(definition.Name) != nil {
+		return nil, invalidArgumentf("template name %s is already used by a built-in template", definition.Name)
+	}
+
+	if _, err := prompt.Parse(definition); err != nil {
+		return nil, invalidArgumentf("%v", err)
+	}
+
+	if err := createDirectoriesIfNotExist(indexPath); err != nil {
+		return nil, fmt.Errorf("failed to create directories: %v", err)
+	}
+
+	registry, err := readTemplates(indexPath)
+	if err != nil {
+		return nil, err
+	}
+
+	definition = proto.Clone(definition).(*pb.PromptTemplate)
+	definition.FirstAddedTime = timestamppb.Now()
+
+	replaced := false
+	for i, t := range registry.Templates {
+		if t.Name != definition.Name {
+			continue
+		}
+		if !replace {
+			return nil, alreadyExistsf("template %s has already been added", definition.Name)
+		}
+		definition.FirstAddedTime = t.FirstAddedTime
+		registry.Templates[i] = definition
+		replaced = true
+	}
+	if !replaced {
+		registry.Templates = append(registry.Templates, definition)
+	}
+
+	if err := writeTemplates(indexPath, registry); err != nil {
+		return nil, err
+	}
+
+	if replaced {
+		fmt.Fprintf(w, "Replaced template %s\n", definition.Name)
+	} else {
+		fmt.Fprintf(w, "Added template %s\n", definition.Name)
+	}
+	return definition, nil
+}
+
+// SubcommandListTemplates lists the built-in templates, then those added to the index.
+func SubcommandListTemplates(indexPath string, w io.Writer) ([]*pb.PromptTemplate, error) {
+	registry, err := readTemplates(indexPath)
+	if err != nil {
+		return nil, err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tSOURCE\tDESCRIPTION")
+
+	templates := prompt.Builtins()
+	for _, t := range templates {
+		fmt.Fprintf(tw, "%s\tbuilt-in\t%s\n", t.Name, t.Description)
+	}
+	for _, t := range registry.Templates {
+		fmt.Fprintf(tw, "%s\tcustom\t%s\n", t.Name, t.Description)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to write the templates: %v", err)
+	}
+
+	return append(templates, registry.Templates...), nil
+}
+
+// SubcommandShowTemplate prints the definition of a template as JSON, in the format accepted by "template add".
+func SubcommandShowTemplate(name string, indexPath string, w io.Writer) (*pb.PromptTemplate, error) {
+	definition, err := findTemplate(indexPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(definition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal template %s: %v", name, err)
+	}
+
+	fmt.Fprintf(w, "%s\n", data)
+	return definition, nil
+}
+
+// loadTemplate finds and parses the template with the given name, the default one if name is empty.
+func loadTemplate(indexPath string, name string) (*prompt.Template, error) {
+	definition, err := findTemplate(indexPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := prompt.Parse(definition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load template %s: %v", definition.Name, err)
+	}
+	return t, nil
+}
+
+// findTemplate returns the definition of the template with the given name, looking it up among the built-in
+// templates, then those added to the index. An empty name is the default template.
+func findTemplate(indexPath string, name string) (*pb.PromptTemplate, error) {
+	if name == "" {
+		name = prompt.DefaultTemplate
+	}
+
+	if definition := prompt.Builtin(name); definition != nil {
+		return definition, nil
+	}
+
+	registry, err := readTemplates(indexPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, definition := range registry.Templates {
+		if definition.Name == name {
+			return definition, nil
+		}
+	}
+
+	return nil, notFoundf("template %s not found", name)
+}
+
+// readTemplates reads the template registry from the index directory. A missing registry results in an empty one.
+func readTemplates(indexPath string) (*pb.PromptTemplateRegistry, error) {
+	registry := &pb.PromptTemplateRegistry{}
+
+	data, err := os.ReadFile(path.Join(indexPath, templatesFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return registry, nil
+		}
+		return nil, fmt.Errorf("failed to read the templates file: %v", err)
+	}
+
+	if err := proto.Unmarshal(data, registry); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the templates file: %v", err)
+	}
+
+	return registry, nil
+}
+
+func writeTemplates(indexPath string, registry *pb.PromptTemplateRegistry) error {
+	data, err := proto.Marshal(registry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the templates file: %v", err)
+	}
+
+	if err := os.WriteFile(path.Join(indexPath, templatesFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write the templates file: %v", err)
+	}
+
+	return nil
+}
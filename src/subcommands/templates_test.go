@@ -0,0 +1,117 @@
+package subcommands
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"strings"
+	"testing"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSubcommandAddTemplate(t *testing.T) {
+	indexPath := t.TempDir()
+	var buf bytes.Buffer
+
+	definition := &pb.PromptTemplate{
+		Name:        "terse",
+		Description: "Short answers",
+		System:      "Answer in one sentence.",
+		Prompt:      "{{.Context}}\n{{.Question}}",
+	}
+	if _, err := SubcommandAddTemplate(definition, false, indexPath, &buf); err != nil {
+		t.Fatalf("SubcommandAddTemplate() returned unexpected error: %v", err)
+	}
+
+	if _, err := SubcommandAddTemplate(definition, false, indexPath, &buf); status.Code(statusError(err)) != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists adding a template twice, got %v", err)
+	}
+
+	definition.System = "Answer in one word."
+	if _, err := SubcommandAddTemplate(definition, true, indexPath, &buf); err != nil {
+		t.Fatalf("SubcommandAddTemplate() returned unexpected error replacing a template: %v", err)
+	}
+
+	invalid := []*pb.PromptTemplate{
+		{Name: "sql", Prompt: "{{.Question}}"},
+		{Name: "1st", Prompt: "{{.Question}}"},
+		{Name: "broken", Prompt: "{{.Question"},
+	}
+	for _, d := range invalid {
+		if _, err := SubcommandAddTemplate(d, true, indexPath, &buf); status.Code(statusError(err)) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument adding template %s, got %v", d.Name, err)
+		}
+	}
+
+	buf.Reset()
+	templates, err := SubcommandListTemplates(indexPath, &buf)
+	if err != nil {
+		t.Fatalf("SubcommandListTemplates() returned unexpected error: %v", err)
+	}
+	if len(templates) != 5 || templates[4].System != "Answer in one word." {
+		t.Errorf("Expected the built-in templates and the replaced one, got %v", templates)
+	}
+	if !strings.Contains(buf.String(), "terse") || !strings.Contains(buf.String(), "built-in") {
+		t.Errorf("Unexpected listing:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if _, err := SubcommandShowTemplate("terse", indexPath, &buf); err != nil {
+		t.Fatalf("SubcommandShowTemplate() returned unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"Answer in one word."`) {
+		t.Errorf("Unexpected definition:\n%s", buf.String())
+	}
+
+	if _, err := SubcommandShowTemplate("missing", indexPath, &buf); err == nil {
+		t.Error("Expected an error showing a missing template, but got nil")
+	}
+}
+
+func TestSubcommandContext_Template(t *testing.T) {
+	indexPath := t.TempDir()
+	indexMap := map[string]*pb.IndexListEntry{
+		"users.proto": {
+			Name:            "users.proto",
+			ContentMetadata: &pb.ContentMetadata{DataType: pb.DataType_PROTO},
+			Chunks: []*pb.Chunk{
+				{EntryName: "users.proto", StartLine: 1, EndLine: 1, Text: "message User { string name = 1; }"},
+			},
+		},
+	}
+	if err := writeIndex(path.Join(indexPath, indexFile), indexMap); err != nil {
+		t.Fatalf("Failed to write index: %v", err)
+	}
+
+	var buf bytes.Buffer
+	definition := &pb.PromptTemplate{
+		Name:        "tagged",
+		Prompt:      "{{.Question}}",
+		ChunkByType: map[string]string{"PROTO": "<{{.DataType}} {{.Number}}>{{.Text}}</{{.DataType}}>"},
+	}
+	if _, err := SubcommandAddTemplate(definition, false, indexPath, &buf); err != nil {
+		t.Fatalf("SubcommandAddTemplate() returned unexpected error: %v", err)
+	}
+
+	request := &pb.ContextRequest{
+		Question: "user message",
+		Template: "tagged",
+		Search:   &pb.SemanticSearchRequest{Mode: pb.SearchMode_LEXICAL},
+	}
+	resp, err := SubcommandContext(context.Background(), nil, request, indexPath, &buf)
+	if err != nil {
+		t.Fatalf("SubcommandContext() returned unexpected error: %v", err)
+	}
+
+	if resp.Context != "<PROTO 1>message User { string name = 1; }</PROTO>\n" || resp.Citations[0].DataType != "PROTO" {
+		t.Errorf("Expected the chunk to be rendered by its type, got %q", resp.Context)
+	}
+
+	request.Template = "missing"
+	if _, err := SubcommandContext(context.Background(), nil, request, indexPath, &buf); err == nil {
+		t.Error("Expected an error for a missing template, but got nil")
+	}
+}
@@ -0,0 +1,247 @@
+package subcommands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bufbuild/protocompile"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	extract "accretional.com/semantifly/extractor"
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+const typesFile = "types.list"
+
+var typeNameRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// SubcommandAddType registers a user defined type backed by a message in a .proto file. The file is compiled
+// along with its imports, and the descriptors are stored in the index so that the file is no longer needed.
+func SubcommandAddType(a *pb.AddTypeRequest, indexPath string, w io.Writer) (*pb.TypeDefinition, error) {
+	if !typeNameRegex.MatchString(a.Name) {
+		return nil, invalidArgumentf("invalid type name %s, expected a letter followed by letters, digits or underscores", a.Name)
+	}
+
+	if _, ok := pb.DataType_value[strings.ToUpper(a.Name)]; ok {
+		return nil, invalidArgumentf("type name %s is already used by a built-in data type", a.Name)
+	}
+
+	if err := createDirectoriesIfNotExist(indexPath); err != nil {
+		return nil, fmt.Errorf("failed to create directories: %v", err)
+	}
+
+	registry, err := readTypes(indexPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range registry.Types {
+		if t.Name == a.Name {
+			return nil, alreadyExistsf("type %s has already been added from %s", a.Name, t.ProtoFile)
+		}
+	}
+
+	definition, err := compileType(a)
+	if err != nil {
+		return nil, err
+	}
+
+	registry.Types = append(registry.Types, definition)
+	if err := writeTypes(indexPath, registry); err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(w, "Added type %s for message %s\n", definition.Name, definition.MessageName)
+	return definition, nil
+}
+
+// compileType compiles the .proto file of an AddTypeRequest and finds the message backing the type.
+func compileType(a *pb.AddTypeRequest) (*pb.TypeDefinition, error) {
+	protoFile, err := filepath.Abs(a.ProtoFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path %s: %v", a.ProtoFile, err)
+	}
+
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{
+			ImportPaths: append([]string{filepath.Dir(protoFile)}, a.ImportPaths...),
+		}),
+	}
+
+	files, err := compiler.Compile(context.Background(), filepath.Base(protoFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile %s: %v", a.ProtoFile, err)
+	}
+
+	message, err := findTypeMessage(files[0], a.Name, a.MessageName)
+	if err != nil {
+		return nil, err
+	}
+
+	fileDescriptorSet, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: fileWithDependencies(files[0])})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal descriptors: %v", err)
+	}
+
+	return &pb.TypeDefinition{
+		Name:              a.Name,
+		MessageName:       string(message.FullName()),
+		FileDescriptorSet: fileDescriptorSet,
+		ProtoFile:         protoFile,
+		FirstAddedTime:    timestamppb.Now(),
+	}, nil
+}
+
+// findTypeMessage chooses the message of a file backing a type: the one named by messageName if given,
+// otherwise the file's only message or the one with the same name as the type.
+func findTypeMessage(file protoreflect.FileDescriptor, typeName string, messageName string) (protoreflect.MessageDescriptor, error) {
+	messages := file.Messages()
+
+	if messageName != "" {
+		if d := file.Messages().ByName(protoreflect.Name(messageName)); d != nil {
+			return d, nil
+		}
+		for i := 0; i < messages.Len(); i++ {
+			if string(messages.Get(i).FullName()) == messageName {
+				return messages.Get(i), nil
+			}
+		}
+		return nil, fmt.Errorf("message %s not found in %s", messageName, file.Path())
+	}
+
+	if messages.Len() == 1 {
+		return messages.Get(0), nil
+	}
+
+	for i := 0; i < messages.Len(); i++ {
+		if strings.EqualFold(string(messages.Get(i).Name()), typeName) {
+			return messages.Get(i), nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s defines %d messages and none is named %s. Choose one with --message", file.Path(), messages.Len(), typeName)
+}
+
+// fileWithDependencies returns the descriptors of a file and all of its transitive imports, dependencies first.
+func fileWithDependencies(file protoreflect.FileDescriptor) []*descriptorpb.FileDescriptorProto {
+	var result []*descriptorpb.FileDescriptorProto
+	seen := make(map[string]bool)
+
+	var visit func(f protoreflect.FileDescriptor)
+	visit = func(f protoreflect.FileDescriptor) {
+		if seen[f.Path()] {
+			return
+		}
+		seen[f.Path()] = true
+
+		imports := f.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			visit(imports.Get(i).FileDescriptor)
+		}
+		result = append(result, protodesc.ToFileDescriptorProto(f))
+	}
+	visit(file)
+
+	return result
+}
+
+// typeMessageDescriptor rebuilds the message descriptor of a stored type definition.
+func typeMessageDescriptor(definition *pb.TypeDefinition) (protoreflect.MessageDescriptor, error) {
+	fileDescriptorSet := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(definition.FileDescriptorSet, fileDescriptorSet); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal descriptors of type %s: %v", definition.Name, err)
+	}
+
+	files, err := protodesc.NewFiles(fileDescriptorSet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build descriptors of type %s: %v", definition.Name, err)
+	}
+
+	d, err := files.FindDescriptorByName(protoreflect.FullName(definition.MessageName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find message %s of type %s: %v", definition.MessageName, definition.Name, err)
+	}
+
+	message, ok := d.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s of type %s is not a message", definition.MessageName, definition.Name)
+	}
+
+	return message, nil
+}
+
+// loadTypes registers the user defined types of an index with the extractor, so that CUSTOM content can be extracted.
+func loadTypes(indexPath string) error {
+	registry, err := readTypes(indexPath)
+	if err != nil {
+		return err
+	}
+
+	for _, definition := range registry.Types {
+		message, err := typeMessageDescriptor(definition)
+		if err != nil {
+			return err
+		}
+		extract.RegisterType(definition.Name, message)
+	}
+
+	return nil
+}
+
+// findType returns the definition of the user defined type with the given name, or nil if there is none.
+func findType(indexPath string, name string) (*pb.TypeDefinition, error) {
+	registry, err := readTypes(indexPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, definition := range registry.Types {
+		if definition.Name == name {
+			return definition, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// readTypes reads the type registry from the index directory. A missing registry results in an empty one.
+func readTypes(indexPath string) (*pb.TypeRegistry, error) {
+	registry := &pb.TypeRegistry{}
+
+	data, err := os.ReadFile(path.Join(indexPath, typesFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return registry, nil
+		}
+		return nil, fmt.Errorf("failed to read the types file: %v", err)
+	}
+
+	if err := proto.Unmarshal(data, registry); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the types file: %v", err)
+	}
+
+	return registry, nil
+}
+
+func writeTypes(indexPath string, registry *pb.TypeRegistry) error {
+	data, err := proto.Marshal(registry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the types file: %v", err)
+	}
+
+	if err := os.WriteFile(path.Join(indexPath, typesFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write the types file: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package subcommands
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	extract "accretional.com/semantifly/extractor"
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSubcommandAddType(t *testing.T) {
+	indexPath := t.TempDir()
+	protoDir := t.TempDir()
+
+	files := map[string]string{
+		"common.proto": `syntax = "proto3";
+package example;
+
+message Address {
+  string city = 1;
+}
+`,
+		"cereal.proto": `syntax = "proto3";
+package example;
+
+import "common.proto";
+import "google/protobuf/timestamp.proto";
+
+message SuperCerealized {
+  string name = 1;
+  Address address = 2;
+  google.protobuf.Timestamp created = 3;
+}
+
+message Other {
+  int32 id = 1;
+}
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(path.Join(protoDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write proto file: %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	definition, err := SubcommandAddType(&pb.AddTypeRequest{
+		Name:      "SuperCerealized",
+		ProtoFile: path.Join(protoDir, "cereal.proto"),
+	}, indexPath, &buf)
+	if err != nil {
+		t.Fatalf("SubcommandAddType() returned unexpected error: %v", err)
+	}
+
+	if definition.MessageName != "example.SuperCerealized" {
+		t.Errorf("Expected message example.SuperCerealized, got %s", definition.MessageName)
+	}
+
+	// the type no longer depends on the .proto files once added
+	if err := os.RemoveAll(protoDir); err != nil {
+		t.Fatalf("Failed to remove proto files: %v", err)
+	}
+
+	if err := loadTypes(indexPath); err != nil {
+		t.Fatalf("loadTypes() returned unexpected error: %v", err)
+	}
+
+	content := []byte(`{"name": "granola", "address": {"city": "Lisbon"}, "created": "2024-05-01T10:00:00Z"}`)
+	text, err := extract.Text(&pb.ContentMetadata{DataType: pb.DataType_CUSTOM, TypeName: "SuperCerealized"}, content)
+	if err != nil {
+		t.Fatalf("Text() returned unexpected error: %v", err)
+	}
+
+	for _, want := range []string{"name: granola", "address.city: Lisbon", "created.seconds: 1714557600"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected text to contain %q, got:\n%s", want, text)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		request *pb.AddTypeRequest
+		errMsg  string
+		code    codes.Code
+	}{
+		{"duplicate", &pb.AddTypeRequest{Name: "SuperCerealized", ProtoFile: "cereal.proto"}, "already been added", codes.AlreadyExists},
+		{"built-in", &pb.AddTypeRequest{Name: "markdown", ProtoFile: "cereal.proto"}, "built-in data type", codes.InvalidArgument},
+		{"invalid name", &pb.AddTypeRequest{Name: "my-type", ProtoFile: "cereal.proto"}, "invalid type name", codes.InvalidArgument},
+		{"missing file", &pb.AddTypeRequest{Name: "Missing", ProtoFile: path.Join(t.TempDir(), "missing.proto")}, "failed to compile", codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := SubcommandAddType(tt.request, indexPath, &buf)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected error containing %q, got %v", tt.errMsg, err)
+			}
+			if code := status.Code(statusError(err)); code != tt.code {
+				t.Errorf("Expected the server to return %v, got %v", tt.code, code)
+			}
+		})
+	}
+}
+
+func TestFindTypeMessage_Ambiguous(t *testing.T) {
+	protoDir := t.TempDir()
+	content := `syntax = "proto3";
+message First {}
+message Second {}
+`
+	if err := os.WriteFile(path.Join(protoDir, "two.proto"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write proto file: %v", err)
+	}
+
+	request := &pb.AddTypeRequest{Name: "Mine", ProtoFile: path.Join(protoDir, "two.proto")}
+	if _, err := compileType(request); err == nil || !strings.Contains(err.Error(), "--message") {
+		t.Errorf("Expected an error asking for --message, got %v", err)
+	}
+
+	request.MessageName = "Second"
+	definition, err := compileType(request)
+	if err != nil {
+		t.Fatalf("compileType() returned unexpected error: %v", err)
+	}
+	if definition.MessageName != "Second" {
+		t.Errorf("Expected message Second, got %s", definition.MessageName)
+	}
+}
@@ -21,8 +21,31 @@ func SubcommandUpdate(ctx context.Context, conn *db.PgxIface, u *pb.UpdateReques
 		return fmt.Errorf("failed to read the index file: %v", err)
 	}
 
-	if err := updateIndex(indexMap, u, w); err != nil {
-		return fmt.Errorf("failed to update the index entry %s: %v", u.Name, err)
+	config, err := readConfig(indexPath)
+	if err != nil {
+		return fmt.Errorf("failed to read the config file: %v", err)
+	}
+
+	if err := setUpdatedDataType(indexMap[u.Name], u, config); err != nil {
+		return err
+	}
+
+	if u.UpdatedMetadata.GetDataType() == pb.DataType_CUSTOM {
+		if err := loadTypes(indexPath); err != nil {
+			return fmt.Errorf("failed to load types: %v", err)
+		}
+
+		definition, err := findType(indexPath, u.UpdatedMetadata.TypeName)
+		if err != nil {
+			return fmt.Errorf("failed to read types: %v", err)
+		}
+		if definition == nil {
+			return invalidArgumentf("unknown data type: %s. Add it with 'add type' first", u.UpdatedMetadata.TypeName)
+		}
+	}
+
+	if err := updateIndex(indexMap, u, config, w); err != nil {
+		return fmt.Errorf("failed to update the index entry %s: %w", u.Name, err)
 	}
 
 	if u.UpdateCopy {
@@ -54,20 +77,38 @@ func SubcommandUpdate(ctx context.Context, conn *db.PgxIface, u *pb.UpdateReques
 	return nil
 }
 
-func updateIndex(indexMap map[string]*pb.IndexListEntry, u *pb.UpdateRequest, w io.Writer) error {
+// setUpdatedDataType sets the DataType of the updated metadata to the one of the entry, or to the one detected for
+// the updated content, as requested. Entries that are missing are left for updateIndex to report.
+func setUpdatedDataType(entry *pb.IndexListEntry, u *pb.UpdateRequest, config *pb.Config) error {
+	switch {
+	case u.DetectDataType:
+		return detectDataType(u.UpdatedMetadata, config)
+
+	case u.KeepDataType && entry != nil:
+		u.UpdatedMetadata.DataType = entry.ContentMetadata.GetDataType()
+		u.UpdatedMetadata.TypeName = entry.ContentMetadata.GetTypeName()
+		u.UpdatedMetadata.DetectedBy = entry.ContentMetadata.GetDetectedBy()
+	}
+
+	return nil
+}
+
+func updateIndex(indexMap map[string]*pb.IndexListEntry, u *pb.UpdateRequest, config *pb.Config, w io.Writer) error {
 	entry, exists := indexMap[u.Name]
 	if !exists {
-		return fmt.Errorf("entry %s not found", u.Name)
+		return notFoundf("entry %s not found", u.Name)
 	}
 
 	entry.ContentMetadata = u.UpdatedMetadata
 
 	entry.LastRefreshedTime = timestamppb.Now()
 
-	err := search.CreateSearchDictionary(entry)
+	previousChunks := entry.Chunks
+	err := search.IndexEntry(entry, chunkingConfig(config, entry.ContentMetadata))
 	if err != nil {
 		fmt.Fprintf(w, "File %s failed to create search dictionary with err: %s. Skipping.\n", entry, err)
 	}
+	carryEmbeddings(previousChunks, entry.Chunks)
 
 	indexMap[u.Name] = entry
 
@@ -2,9 +2,11 @@ package subcommands
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"path"
+	"strings"
 	"testing"
 
 	"accretional.com/semantifly/database"
@@ -264,3 +266,55 @@ func TestUpdate_Database(t *testing.T) {
 		t.Errorf("FirstAddedTime is nil")
 	}
 }
+
+func TestSetUpdatedDataType(t *testing.T) {
+	entry := &pb.IndexListEntry{
+		Name:            "guide.md",
+		ContentMetadata: &pb.ContentMetadata{URI: "guide.md", DataType: pb.DataType_MARKDOWN, DetectedBy: "extension .md"},
+	}
+
+	tests := []struct {
+		name     string
+		request  *pb.UpdateRequest
+		expected pb.DataType
+	}{
+		{"keep", &pb.UpdateRequest{KeepDataType: true}, pb.DataType_MARKDOWN},
+		{"detect", &pb.UpdateRequest{DetectDataType: true}, pb.DataType_YAML},
+		{"given", &pb.UpdateRequest{UpdatedMetadata: &pb.ContentMetadata{DataType: pb.DataType_TEXT}}, pb.DataType_TEXT},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.request.UpdatedMetadata == nil {
+				tt.request.UpdatedMetadata = &pb.ContentMetadata{URI: "guide.yaml"}
+			}
+			if err := setUpdatedDataType(entry, tt.request, nil); err != nil {
+				t.Fatalf("setUpdatedDataType() returned unexpected error: %v", err)
+			}
+			if tt.request.UpdatedMetadata.DataType != tt.expected {
+				t.Errorf("Expected DataType %v, got %v", tt.expected, tt.request.UpdatedMetadata.DataType)
+			}
+		})
+	}
+}
+
+func TestUpdate_UnknownType(t *testing.T) {
+	indexPath := t.TempDir()
+	indexMap := map[string]*pb.IndexListEntry{
+		"notes.txt": {Name: "notes.txt", ContentMetadata: &pb.ContentMetadata{URI: "notes.txt"}},
+	}
+	if err := writeIndex(path.Join(indexPath, indexFile), indexMap); err != nil {
+		t.Fatalf("Failed to write index: %v", err)
+	}
+
+	updateArgs := &pb.UpdateRequest{
+		Name:            "notes.txt",
+		UpdatedMetadata: &pb.ContentMetadata{URI: "notes.txt", DataType: pb.DataType_CUSTOM, TypeName: "SuperCerealized"},
+	}
+
+	var buf bytes.Buffer
+	err := SubcommandUpdate(context.Background(), nil, updateArgs, indexPath, &buf)
+	if err == nil || !strings.Contains(err.Error(), "unknown data type: SuperCerealized") {
+		t.Errorf("Expected an error for an unregistered type, got %v", err)
+	}
+}
@@ -7,15 +7,20 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"unicode/utf8"
 
+	extract "accretional.com/semantifly/extractor"
 	fetch "accretional.com/semantifly/fetcher"
 	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const addedCopiesSubDir = "add_cache"
 const indexFile = "index.list"
+const configFile = "config.json"
 
 // readIndex reads an index file from the given path and returns a map of IndexListEntry objects.
 // If the file is not found and ignoreIfNotFound is true, it returns an empty map.
@@ -103,17 +108,18 @@ func makeCopy(indexPath string, ile *pb.IndexListEntry) error {
 	ileCopy := &pb.IndexListEntry{
 		Name: ile.Name,
 
-		ContentMetadata: &pb.ContentMetadata{
-			URI:        ile.ContentMetadata.URI,
-			DataType:   ile.ContentMetadata.DataType,
-			SourceType: ile.ContentMetadata.SourceType,
-		},
+		ContentMetadata: proto.Clone(ile.ContentMetadata).(*pb.ContentMetadata),
 
 		FirstAddedTime:    ile.FirstAddedTime,
-		Content:           string(content),
 		LastRefreshedTime: timestamppb.Now(),
 	}
 
+	if utf8.Valid(content) {
+		ileCopy.Content = string(content)
+	} else {
+		ileCopy.BinaryContent = content
+	}
+
 	dest := path.Join(indexPath, addedCopiesSubDir, ile.Name)
 	dir := filepath.Dir(dest)
 	if err := os.MkdirAll(dir, 0770); err != nil {
@@ -164,6 +170,10 @@ func fetchFromCopy(indexPath string, name string) ([]byte, error) {
 		return nil, fmt.Errorf("failed to unmarshal IndexListEntry: %w", err)
 	}
 
+	if ile.BinaryContent != nil {
+		return ile.BinaryContent, nil
+	}
+
 	if ile.Content == "" {
 		return nil, nil
 	}
@@ -171,6 +181,75 @@ func fetchFromCopy(indexPath string, name string) ([]byte, error) {
 	return []byte(ile.Content), nil
 }
 
+// readConfig reads the config file from the index directory. A missing config file results in an empty Config.
+//
+// Parameters:
+//   - indexPath: The path to the index directory.
+func readConfig(indexPath string) (*pb.Config, error) {
+	config := &pb.Config{}
+
+	data, err := os.ReadFile(path.Join(indexPath, configFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := protojson.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	return config, nil
+}
+
+// chunkingConfig returns the chunking settings of the config for content with the given metadata:
+// those for its type if there are any, or the general ones otherwise.
+//
+// Parameters:
+//   - config: The config of the index.
+//   - metadata: The metadata of the content to chunk.
+func chunkingConfig(config *pb.Config, metadata *pb.ContentMetadata) *pb.ChunkingConfig {
+	if chunking, ok := config.GetChunkingByType()[typeName(metadata)]; ok {
+		return chunking
+	}
+	return config.GetChunking()
+}
+
+// typeName returns the name settings by type are keyed by for content with the given metadata: the name of its
+// DataType, eg. "MARKDOWN", or of its user defined type.
+func typeName(metadata *pb.ContentMetadata) string {
+	if metadata.GetDataType() == pb.DataType_CUSTOM {
+		return metadata.GetTypeName()
+	}
+	return metadata.GetDataType().String()
+}
+
+// detectDataType sets the DataType of the metadata to the one detected for its content, along with how it was
+// detected. Content is only fetched when the URI alone is not enough to decide.
+//
+// Parameters:
+//   - metadata: The metadata of the content, with its URI and SourceType set.
+//   - config: The config holding overrides of the detection.
+func detectDataType(metadata *pb.ContentMetadata, config *pb.Config) error {
+	// documents of YAML streams are detected by the name of the stream
+	uri := metadata.URI
+	if streamURI, _, ok := fetch.SplitYAMLDocumentURI(uri); ok {
+		uri = streamURI
+	}
+
+	dataType, detectedBy, err := extract.DetectDataType(uri, config.GetDataTypes(), func() ([]byte, string, error) {
+		return fetch.FetchWithContentType(metadata.SourceType, metadata.URI)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to detect data type of %s: %w", metadata.URI, err)
+	}
+
+	metadata.DataType = dataType
+	metadata.DetectedBy = detectedBy
+	return nil
+}
+
 // parseDataType converts a string representation of a data type to its corresponding pb.DataType enum value.
 // It returns the parsed pb.DataType and an error if the input string is not a valid data type.
 //
@@ -192,15 +271,80 @@ func parseDataType(str string) (pb.DataType, error) {
 func parseSourceType(str string) (pb.SourceType, error) {
 	val, ok := pb.SourceType_value[strings.ToUpper(str)]
 	if !ok {
-		return pb.SourceType_LOCAL_FILE, fmt.Errorf("unknown source type: %s", str)
+		return pb.SourceType_LOCAL_FILE, invalidArgumentf("unknown source type: %s", str)
 	}
 	return pb.SourceType(val), nil
 }
 
+// parseExtractOptions converts a comma separated list of key=value pairs into a map of extractor options.
+// An empty string results in a nil map.
+//
+// Parameters:
+//   - str: A string of options, eg. "outputs=true,foo=bar".
+func parseExtractOptions(str string) (map[string]string, error) {
+	if str == "" {
+		return nil, nil
+	}
+
+	options := make(map[string]string)
+	for _, pair := range strings.Split(str, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid extract option %q, expected key=value", pair)
+		}
+		options[key] = strings.TrimSpace(value)
+	}
+
+	return options, nil
+}
+
 func parseIndexSource(str string) (pb.IndexSource, error) {
 	val, ok := pb.IndexSource_value[strings.ToUpper(str)]
 	if !ok {
-		return pb.IndexSource_INDEX_FILE, fmt.Errorf("unknown index source type: %s", str)
+		return pb.IndexSource_INDEX_FILE, invalidArgumentf("unknown index source type: %s", str)
 	}
 	return pb.IndexSource(val), nil
 }
+
+func parseSearchMode(str string) (pb.SearchMode, error) {
+	val, ok := pb.SearchMode_value[strings.ToUpper(str)]
+	if !ok {
+		return pb.SearchMode_SEMANTIC, invalidArgumentf("unknown search mode: %s", str)
+	}
+	return pb.SearchMode(val), nil
+}
+
+func parseFusion(str string) (pb.Fusion, error) {
+	val, ok := pb.Fusion_value[strings.ToUpper(str)]
+	if !ok {
+		return pb.Fusion_RRF, invalidArgumentf("unknown fusion: %s", str)
+	}
+	return pb.Fusion(val), nil
+}
+
+// requestError is an error caused by the request rather than by the index or its sources, eg. a missing entry. The
+// server returns it with its code instead of Internal.
+type requestError struct {
+	code    codes.Code
+	message string
+}
+
+func (e *requestError) Error() string {
+	return e.message
+}
+
+// notFoundf formats an error for an entry, or a part of one, a type or a template that does not exist.
+func notFoundf(format string, a ...any) error {
+	return &requestError{code: codes.NotFound, message: fmt.Sprintf(format, a...)}
+}
+
+// invalidArgumentf formats an error for a request that is invalid whatever the state of the index.
+func invalidArgumentf(format string, a ...any) error {
+	return &requestError{code: codes.InvalidArgument, message: fmt.Sprintf(format, a...)}
+}
+
+// alreadyExistsf formats an error for a type or a template that has already been added.
+func alreadyExistsf(format string, a ...any) error {
+	return &requestError{code: codes.AlreadyExists, message: fmt.Sprintf(format, a...)}
+}
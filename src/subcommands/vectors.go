@@ -0,0 +1,142 @@
+package subcommands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+	"accretional.com/semantifly/vectorindex"
+)
+
+const vectorIndexFile = "vectors.hnsw"
+
+// chunkID identifies the vector of a chunk in the vector index.
+func chunkID(entryName string, ordinal int32) string {
+	return fmt.Sprintf("%s#%d", entryName, ordinal)
+}
+
+func vectorIndexOptions(config *pb.Config) vectorindex.Options {
+	c := config.GetVectorIndex()
+	return vectorindex.Options{
+		Metric:         c.GetMetric(),
+		M:              int(c.GetM()),
+		EfConstruction: int(c.GetEfConstruction()),
+		EfSearch:       int(c.GetEfSearch()),
+	}
+}
+
+// loadVectorIndex reads the vector index of an index directory, or returns nil if there is none.
+func loadVectorIndex(indexPath string, config *pb.Config) (*vectorindex.Index, error) {
+	ix, err := vectorindex.Load(path.Join(indexPath, vectorIndexFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	ix.SetEfSearch(int(config.GetVectorIndex().GetEfSearch()))
+	return ix, nil
+}
+
+// syncVectorIndex brings the vector index in line with the embeddings of the chunks of the index: vectors of
+// new or changed chunks are inserted, and those of chunks that no longer exist are deleted. Only embeddings
+// of the given model are indexed. The index is rebuilt when the model, dimension or graph options change.
+func syncVectorIndex(indexPath string, indexMap map[string]*pb.IndexListEntry, model string, config *pb.Config, w io.Writer) error {
+	filePath := path.Join(indexPath, vectorIndexFile)
+
+	embeddings := make(map[string]*pb.Embedding)
+	dimension := 0
+	for _, entry := range indexMap {
+		for _, chunk := range entry.Chunks {
+			if embeddingUpToDate(chunk, model) {
+				embeddings[chunkID(entry.Name, chunk.Ordinal)] = chunk.Embedding
+				dimension = len(chunk.Embedding.Vector)
+			}
+		}
+	}
+
+	if len(embeddings) == 0 {
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove the vector index: %v", err)
+		}
+		return nil
+	}
+
+	options := vectorIndexOptions(config)
+	ix, err := loadVectorIndex(indexPath, config)
+	if err != nil {
+		fmt.Fprintf(w, "Failed to load the vector index, rebuilding it: %v\n", err)
+	}
+
+	if ix != nil && !sameGraph(ix, model, dimension, options) {
+		fmt.Fprintf(w, "Rebuilding the vector index for %s with %d dimensions\n", model, dimension)
+		ix = nil
+	}
+
+	if ix == nil {
+		ix, err = vectorindex.New(model, dimension, options)
+		if err != nil {
+			return fmt.Errorf("failed to create the vector index: %v", err)
+		}
+	}
+
+	deleted := 0
+	for _, id := range ix.IDs() {
+		if _, ok := embeddings[id]; !ok {
+			ix.Delete(id)
+			deleted++
+		}
+	}
+
+	ids := make([]string, 0, len(embeddings))
+	for id := range embeddings {
+		ids = append(ids, id)
+	}
+	// inserting in a stable order keeps the graph the same for the same vectors
+	sort.Strings(ids)
+
+	inserted := 0
+	for _, id := range ids {
+		embedding := embeddings[id]
+		if digest, ok := ix.Digest(id); ok && digest == embedding.Digest {
+			continue
+		}
+		if err := ix.Insert(id, embedding.Digest, embedding.Vector); err != nil {
+			return fmt.Errorf("failed to index the vector of %s: %v", id, err)
+		}
+		inserted++
+	}
+
+	if inserted == 0 && deleted == 0 {
+		return nil
+	}
+
+	if err := ix.Save(filePath); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "Vector index: %d vectors inserted, %d deleted, %d in total\n", inserted, deleted, ix.Len())
+	return nil
+}
+
+// sameGraph reports whether a vector index holds vectors of the model and dimension, built with the same options.
+func sameGraph(ix *vectorindex.Index, model string, dimension int, options vectorindex.Options) bool {
+	if options.Metric == "" {
+		options.Metric = vectorindex.Cosine
+	}
+	if options.M <= 0 {
+		options.M = vectorindex.DefaultM
+	}
+	if options.EfConstruction <= 0 {
+		options.EfConstruction = vectorindex.DefaultEfConstruction
+	}
+
+	current := ix.Options()
+	return ix.Model() == model && ix.Dimension() == dimension && current.Metric == options.Metric &&
+		current.M == options.M && current.EfConstruction == options.EfConstruction
+}
@@ -0,0 +1,66 @@
+package subcommands
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+func TestSyncVectorIndex(t *testing.T) {
+	indexPath := t.TempDir()
+	config := &pb.Config{}
+
+	embedded := func(ordinal int32, digest string, vector ...float32) *pb.Chunk {
+		return &pb.Chunk{Ordinal: ordinal, Digest: digest, Embedding: &pb.Embedding{Model: "hash-2", Digest: digest, Vector: vector}}
+	}
+	indexMap := map[string]*pb.IndexListEntry{
+		"a": {Name: "a", Chunks: []*pb.Chunk{embedded(0, "x", 1, 0), embedded(1, "y", 0, 1)}},
+		"b": {Name: "b", Chunks: []*pb.Chunk{embedded(0, "z", 1, 1), {Ordinal: 1, Digest: "unembedded"}}},
+	}
+
+	var buf bytes.Buffer
+	if err := syncVectorIndex(indexPath, indexMap, "hash-2", config, &buf); err != nil {
+		t.Fatalf("syncVectorIndex() returned unexpected error: %v", err)
+	}
+
+	ix, err := loadVectorIndex(indexPath, config)
+	if err != nil || ix == nil {
+		t.Fatalf("Failed to load the vector index: %v", err)
+	}
+	if !reflect.DeepEqual(ix.IDs(), []string{"a#0", "a#1", "b#0"}) {
+		t.Errorf("Unexpected vectors in the index: %v", ix.IDs())
+	}
+
+	results, err := ix.Search([]float32{0, 1}, 1)
+	if err != nil || len(results) != 1 || results[0].ID != "a#1" {
+		t.Errorf("Expected a#1 to be the closest vector, got %v (%v)", results, err)
+	}
+
+	// deleted entries and changed chunks are reflected incrementally
+	delete(indexMap, "b")
+	indexMap["a"].Chunks[1] = embedded(1, "w", -1, 0)
+	buf.Reset()
+	if err := syncVectorIndex(indexPath, indexMap, "hash-2", config, &buf); err != nil {
+		t.Fatalf("syncVectorIndex() returned unexpected error: %v", err)
+	}
+	if buf.String() != "Vector index: 1 vectors inserted, 1 deleted, 2 in total\n" {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+
+	ix, _ = loadVectorIndex(indexPath, config)
+	if digest, _ := ix.Digest("a#1"); digest != "w" || ix.Len() != 2 {
+		t.Errorf("Expected the changed chunk to be inserted again, got digest %s and %d vectors", digest, ix.Len())
+	}
+
+	// a different model rebuilds the index, and no vectors at all removes it
+	if err := syncVectorIndex(indexPath, indexMap, "other", config, &buf); err != nil {
+		t.Fatalf("syncVectorIndex() returned unexpected error: %v", err)
+	}
+	if _, err := os.Stat(path.Join(indexPath, vectorIndexFile)); !os.IsNotExist(err) {
+		t.Errorf("Expected the vector index to be removed without vectors of the model, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package tokens
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"accretional.com/semantifly/chunker"
+)
+
+// Counter counts the tokens of a text the way a model's tokenizer would, or approximates it.
+type Counter interface {
+	Count(text string) int
+}
+
+// CounterFunc adapts a function to a Counter.
+type CounterFunc func(text string) int
+
+func (f CounterFunc) Count(text string) int {
+	return f(text)
+}
+
+type CounterInfo struct {
+	Description string
+	Counter     Counter
+}
+
+var counterDict = map[string]CounterInfo{
+	"approx": {
+		Description: "Words and punctuation marks, as counted by the chunkers; close to BPE tokenizers for English prose",
+		Counter:     CounterFunc(chunker.CountTokens),
+	},
+	"chars": {
+		Description: "A token per 4 bytes, the usual rule of thumb for OpenAI models",
+		Counter:     CounterFunc(func(text string) int { return (len(text) + 3) / 4 }),
+	},
+	"words": {
+		Description: "Whitespace separated words",
+		Counter:     CounterFunc(func(text string) int { return len(strings.Fields(text)) }),
+	},
+}
+
+// Names returns the names of the available counters, sorted.
+func Names() []string {
+	names := make([]string, 0, len(counterDict))
+	for name := range counterDict {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New returns the counter with the given name, "approx" if empty.
+func New(name string) (Counter, error) {
+	if name == "" {
+		name = "approx"
+	}
+
+	info, ok := counterDict[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown tokenizer %s, expected one of %s", name, strings.Join(Names(), ", "))
+	}
+
+	return info.Counter, nil
+}
+
+// Truncate returns the longest prefix of text with at most maxTokens tokens, cut after a line break or else after
+// a space when one ends the second half of the prefix, so that lines or words are not split.
+func Truncate(counter Counter, text string, maxTokens int) string {
+	if maxTokens <= 0 {
+		return ""
+	}
+	if counter.Count(text) <= maxTokens {
+		return text
+	}
+
+	// longest fitting prefix by bisection over the character boundaries of text
+	var boundaries []int
+	for i := range text {
+		boundaries = append(boundaries, i)
+	}
+	n := sort.Search(len(boundaries), func(i int) bool {
+		return counter.Count(text[:boundaries[i]]) > maxTokens
+	})
+	prefix := text[:boundaries[n-1]]
+
+	if i := strings.LastIndexByte(prefix, '\n'); i >= len(prefix)/2 {
+		return prefix[:i+1]
+	}
+	if i := strings.LastIndexAny(prefix, " \t"); i >= len(prefix)/2 {
+		return prefix[:i+1]
+	}
+	return prefix
+}
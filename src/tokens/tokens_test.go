@@ -0,0 +1,58 @@
+package tokens
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected int
+	}{
+		{"", "Hello, world!", 4},
+		{"approx", "Hello, world!", 4},
+		{"chars", "Hello, world!", 4},
+		{"words", "Hello, world!", 2},
+	}
+
+	for _, tt := range tests {
+		counter, err := New(tt.name)
+		if err != nil {
+			t.Fatalf("New(%q) returned unexpected error: %v", tt.name, err)
+		}
+		if got := counter.Count(tt.text); got != tt.expected {
+			t.Errorf("Counter %q counted %d tokens, expected %d", tt.name, got, tt.expected)
+		}
+	}
+
+	if _, err := New("unknown"); err == nil {
+		t.Error("Expected an error for an unknown tokenizer")
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	counter, _ := New("words")
+
+	text := "one two three\nfour five six\nseven eight nine"
+	if got := Truncate(counter, text, 9); got != text {
+		t.Errorf("Expected a text within the budget to be kept, got %q", got)
+	}
+	if got := Truncate(counter, text, 7); got != "one two three\nfour five six\n" {
+		t.Errorf("Expected the text to be cut after a line, got %q", got)
+	}
+	if got := Truncate(counter, "one two three four five six", 4); got != "one two three four " {
+		t.Errorf("Expected the text to be cut after a word, got %q", got)
+	}
+	if got := Truncate(counter, text, 0); got != "" {
+		t.Errorf("Expected nothing to be kept without a budget, got %q", got)
+	}
+
+	// characters are never split
+	chars, _ := New("chars")
+	accented := strings.Repeat("é", 10)
+	if got := Truncate(chars, accented, 2); got != "éééé" {
+		t.Errorf("Expected 4 characters of 8 bytes, got %q", got)
+	}
+}
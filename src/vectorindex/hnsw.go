@@ -0,0 +1,454 @@
+package vectorindex
+
+import (
+	"container/heap"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sort"
+)
+
+const (
+	DefaultM              = 16
+	DefaultEfConstruction = 200
+	DefaultEfSearch       = 64
+)
+
+// Distance metrics. Scores are the cosine similarity or the dot product, higher meaning closer.
+const (
+	Cosine = "cosine"
+	Dot    = "dot"
+)
+
+// Options tune the graph. M and EfConstruction shape it when vectors are inserted, while EfSearch only affects
+// searches and can be changed at any time.
+type Options struct {
+	Metric         string
+	M              int
+	EfConstruction int
+	EfSearch       int
+}
+
+// Result is a vector found by a search, with its score against the query.
+type Result struct {
+	ID    string
+	Score float32
+}
+
+type node struct {
+	id        string
+	digest    string
+	vector    []float32
+	deleted   bool
+	neighbors [][]int32
+}
+
+// Index is an approximate nearest neighbour index of vectors using a hierarchical navigable small world graph.
+// Each vector is a node linked to its closest neighbours on level 0 and, with exponentially decreasing
+// probability, on higher levels; searches descend greedily from the top level. Deleted vectors stay in
+// the graph as tombstones until the index is compacted. An Index is not safe for concurrent use.
+type Index struct {
+	model     string
+	dimension int
+	options   Options
+
+	nodes      []*node
+	ids        map[string]int32
+	entryPoint int32
+	maxLevel   int
+	deleted    int
+}
+
+// New creates an empty index of vectors of the given model and dimension, with defaults for unset options.
+func New(model string, dimension int, options Options) (*Index, error) {
+	if dimension <= 0 {
+		return nil, fmt.Errorf("invalid dimension %d", dimension)
+	}
+
+	options, err := resolveOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Index{
+		model:      model,
+		dimension:  dimension,
+		options:    options,
+		ids:        make(map[string]int32),
+		entryPoint: -1,
+	}, nil
+}
+
+func resolveOptions(options Options) (Options, error) {
+	switch options.Metric {
+	case "":
+		options.Metric = Cosine
+	case Cosine, Dot:
+	default:
+		return options, fmt.Errorf("unknown metric %s, expected %s or %s", options.Metric, Cosine, Dot)
+	}
+	if options.M <= 0 {
+		options.M = DefaultM
+	}
+	if options.M < 2 {
+		options.M = 2
+	}
+	if options.EfConstruction <= 0 {
+		options.EfConstruction = DefaultEfConstruction
+	}
+	if options.EfSearch <= 0 {
+		options.EfSearch = DefaultEfSearch
+	}
+	return options, nil
+}
+
+func (ix *Index) Model() string {
+	return ix.model
+}
+
+func (ix *Index) Dimension() int {
+	return ix.dimension
+}
+
+func (ix *Index) Options() Options {
+	return ix.options
+}
+
+// SetEfSearch changes the number of candidates considered by searches.
+func (ix *Index) SetEfSearch(efSearch int) {
+	if efSearch > 0 {
+		ix.options.EfSearch = efSearch
+	}
+}
+
+// Len returns the number of vectors in the index, not counting deleted ones.
+func (ix *Index) Len() int {
+	return len(ix.ids)
+}
+
+// Digest returns the digest the vector with the given id was inserted with, and whether there is one.
+func (ix *Index) Digest(id string) (string, bool) {
+	n, ok := ix.ids[id]
+	if !ok {
+		return "", false
+	}
+	return ix.nodes[n].digest, true
+}
+
+// IDs returns the ids of the vectors in the index, sorted.
+func (ix *Index) IDs() []string {
+	ids := make([]string, 0, len(ix.ids))
+	for id := range ix.ids {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Insert adds a vector to the index, replacing the one with the same id if there is one. The digest records
+// what the vector was computed from, so that callers can tell whether it needs to be inserted again.
+func (ix *Index) Insert(id string, digest string, vector []float32) error {
+	if len(vector) != ix.dimension {
+		return fmt.Errorf("vector %s has %d dimensions, expected %d", id, len(vector), ix.dimension)
+	}
+
+	ix.Delete(id)
+
+	v := make([]float32, len(vector))
+	copy(v, vector)
+	if ix.options.Metric == Cosine {
+		normalize(v)
+	}
+
+	level := ix.randomLevel(id)
+	n := int32(len(ix.nodes))
+	ix.nodes = append(ix.nodes, &node{id: id, digest: digest, vector: v, neighbors: make([][]int32, level+1)})
+	ix.ids[id] = n
+
+	if ix.entryPoint < 0 {
+		ix.entryPoint = n
+		ix.maxLevel = level
+		return nil
+	}
+
+	entryPoint := ix.entryPoint
+	for l := ix.maxLevel; l > level; l-- {
+		entryPoint = ix.searchLayer(v, []int32{entryPoint}, 1, l)[0].node
+	}
+
+	entryPoints := []int32{entryPoint}
+	for l := min(level, ix.maxLevel); l >= 0; l-- {
+		candidates := ix.searchLayer(v, entryPoints, ix.options.EfConstruction, l)
+
+		neighbors := ix.selectNeighbors(candidates, ix.options.M)
+		ix.nodes[n].neighbors[l] = neighbors
+		for _, neighbor := range neighbors {
+			ix.connect(neighbor, n, l)
+		}
+
+		entryPoints = entryPoints[:0]
+		for _, c := range candidates {
+			entryPoints = append(entryPoints, c.node)
+		}
+	}
+
+	if level > ix.maxLevel {
+		ix.entryPoint = n
+		ix.maxLevel = level
+	}
+
+	return nil
+}
+
+// Delete removes the vector with the given id from the results of searches, and reports whether there was one.
+func (ix *Index) Delete(id string) bool {
+	n, ok := ix.ids[id]
+	if !ok {
+		return false
+	}
+
+	ix.nodes[n].deleted = true
+	delete(ix.ids, id)
+	ix.deleted++
+	return true
+}
+
+// NeedsCompaction reports whether deleted vectors outnumber the others, slowing down searches.
+func (ix *Index) NeedsCompaction() bool {
+	return ix.deleted > len(ix.ids)
+}
+
+// Compact rebuilds the graph from the vectors that were not deleted.
+func (ix *Index) Compact() {
+	nodes := ix.nodes
+	ix.nodes = nil
+	ix.ids = make(map[string]int32)
+	ix.entryPoint = -1
+	ix.maxLevel = 0
+	ix.deleted = 0
+
+	for _, n := range nodes {
+		if !n.deleted {
+			// vectors already have the right dimension and are normalized
+			ix.Insert(n.id, n.digest, n.vector)
+		}
+	}
+}
+
+// Search returns the k vectors closest to the query, closest first.
+func (ix *Index) Search(query []float32, k int) ([]Result, error) {
+	if len(query) != ix.dimension {
+		return nil, fmt.Errorf("query has %d dimensions, expected %d", len(query), ix.dimension)
+	}
+	if ix.entryPoint < 0 || k <= 0 {
+		return nil, nil
+	}
+
+	q := make([]float32, len(query))
+	copy(q, query)
+	if ix.options.Metric == Cosine {
+		normalize(q)
+	}
+
+	entryPoint := ix.entryPoint
+	for l := ix.maxLevel; l > 0; l-- {
+		entryPoint = ix.searchLayer(q, []int32{entryPoint}, 1, l)[0].node
+	}
+
+	// deleted nodes take up candidate slots, so more are considered when there are many
+	ef := max(ix.options.EfSearch, k)
+	if ix.deleted > 0 {
+		ef += ef * ix.deleted / max(len(ix.ids), 1)
+	}
+
+	var results []Result
+	for _, c := range ix.searchLayer(q, []int32{entryPoint}, ef, 0) {
+		if ix.nodes[c.node].deleted {
+			continue
+		}
+		results = append(results, Result{ID: ix.nodes[c.node].id, Score: -c.distance})
+		if len(results) == k {
+			break
+		}
+	}
+
+	return results, nil
+}
+
+// randomLevel draws the level of a node from an exponential distribution with a mean of 1/ln(M). The draw is
+// derived from the id so that building an index from the same vectors always results in the same graph.
+func (ix *Index) randomLevel(id string) int {
+	hasher := fnv.New64a()
+	hasher.Write([]byte(id))
+	uniform := (float64(hasher.Sum64()>>11) + 0.5) / float64(1<<53)
+
+	return int(-math.Log(uniform) / math.Log(float64(ix.options.M)))
+}
+
+func (ix *Index) maxConnections(level int) int {
+	if level == 0 {
+		return 2 * ix.options.M
+	}
+	return ix.options.M
+}
+
+// distance is the negated score, so that smaller is closer for both metrics.
+func (ix *Index) distance(a, b []float32) float32 {
+	var dot float32
+	for i := range a {
+		dot += a[i] * b[i]
+	}
+	return -dot
+}
+
+type candidate struct {
+	node     int32
+	distance float32
+}
+
+// searchLayer returns up to ef nodes of a level closest to the query, closest first, exploring the graph
+// from the entry points.
+func (ix *Index) searchLayer(query []float32, entryPoints []int32, ef int, level int) []candidate {
+	visited := make(map[int32]bool)
+	candidates := &nearestFirst{}
+	results := &furthestFirst{}
+
+	for _, ep := range entryPoints {
+		if visited[ep] {
+			continue
+		}
+		visited[ep] = true
+		c := candidate{ep, ix.distance(query, ix.nodes[ep].vector)}
+		heap.Push(candidates, c)
+		heap.Push(results, c)
+	}
+	for results.Len() > ef {
+		heap.Pop(results)
+	}
+
+	for candidates.Len() > 0 {
+		c := heap.Pop(candidates).(candidate)
+		if results.Len() >= ef && c.distance > (*results)[0].distance {
+			break
+		}
+
+		for _, neighbor := range ix.nodes[c.node].neighbors[level] {
+			if visited[neighbor] {
+				continue
+			}
+			visited[neighbor] = true
+
+			d := ix.distance(query, ix.nodes[neighbor].vector)
+			if results.Len() < ef || d < (*results)[0].distance {
+				heap.Push(candidates, candidate{neighbor, d})
+				heap.Push(results, candidate{neighbor, d})
+				if results.Len() > ef {
+					heap.Pop(results)
+				}
+			}
+		}
+	}
+
+	sorted := make([]candidate, results.Len())
+	for i := len(sorted) - 1; i >= 0; i-- {
+		sorted[i] = heap.Pop(results).(candidate)
+	}
+	return sorted
+}
+
+// selectNeighbors chooses up to m neighbours among candidates sorted closest first. A candidate is preferred
+// when it is closer to the node than to any neighbour already chosen, which keeps links spread out in
+// different directions; the closest remaining candidates fill any slots left.
+func (ix *Index) selectNeighbors(candidates []candidate, m int) []int32 {
+	var selected []int32
+	var pruned []int32
+
+	for _, c := range candidates {
+		if len(selected) >= m {
+			break
+		}
+
+		diverse := true
+		for _, s := range selected {
+			if ix.distance(ix.nodes[c.node].vector, ix.nodes[s].vector) < c.distance {
+				diverse = false
+				break
+			}
+		}
+
+		if diverse {
+			selected = append(selected, c.node)
+		} else {
+			pruned = append(pruned, c.node)
+		}
+	}
+
+	for _, p := range pruned {
+		if len(selected) >= m {
+			break
+		}
+		selected = append(selected, p)
+	}
+
+	return selected
+}
+
+// connect links node n from the given neighbour, pruning the neighbour's links if it has too many.
+func (ix *Index) connect(neighbor int32, n int32, level int) {
+	nb := ix.nodes[neighbor]
+	nb.neighbors[level] = append(nb.neighbors[level], n)
+	if len(nb.neighbors[level]) <= ix.maxConnections(level) {
+		return
+	}
+
+	candidates := make([]candidate, len(nb.neighbors[level]))
+	for i, other := range nb.neighbors[level] {
+		candidates[i] = candidate{other, ix.distance(nb.vector, ix.nodes[other].vector)}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].distance < candidates[j].distance })
+
+	nb.neighbors[level] = ix.selectNeighbors(candidates, ix.maxConnections(level))
+}
+
+func normalize(vector []float32) {
+	var sum float64
+	for _, v := range vector {
+		sum += float64(v) * float64(v)
+	}
+	if sum == 0 {
+		return
+	}
+
+	norm := float32(math.Sqrt(sum))
+	for i := range vector {
+		vector[i] /= norm
+	}
+}
+
+// nearestFirst is a min-heap of candidates by distance.
+type nearestFirst []candidate
+
+func (h nearestFirst) Len() int           { return len(h) }
+func (h nearestFirst) Less(i, j int) bool { return h[i].distance < h[j].distance }
+func (h nearestFirst) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *nearestFirst) Push(x any)        { *h = append(*h, x.(candidate)) }
+func (h *nearestFirst) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
+
+// furthestFirst is a max-heap of candidates by distance.
+type furthestFirst []candidate
+
+func (h furthestFirst) Len() int           { return len(h) }
+func (h furthestFirst) Less(i, j int) bool { return h[i].distance > h[j].distance }
+func (h furthestFirst) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *furthestFirst) Push(x any)        { *h = append(*h, x.(candidate)) }
+func (h *furthestFirst) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
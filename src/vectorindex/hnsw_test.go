@@ -0,0 +1,179 @@
+package vectorindex
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func randomVectors(n int, dimension int, seed int64) [][]float32 {
+	r := rand.New(rand.NewSource(seed))
+	vectors := make([][]float32, n)
+	for i := range vectors {
+		vectors[i] = make([]float32, dimension)
+		for j := range vectors[i] {
+			vectors[i][j] = float32(r.NormFloat64())
+		}
+	}
+	return vectors
+}
+
+// bruteForce returns the ids of the k vectors with the highest cosine similarity to the query.
+func bruteForce(vectors [][]float32, query []float32, k int) []string {
+	type scored struct {
+		id    string
+		score float64
+	}
+	var all []scored
+	for i, v := range vectors {
+		var dot, normV, normQ float64
+		for j := range v {
+			dot += float64(v[j]) * float64(query[j])
+			normV += float64(v[j]) * float64(v[j])
+			normQ += float64(query[j]) * float64(query[j])
+		}
+		all = append(all, scored{fmt.Sprint(i), dot / math.Sqrt(normV*normQ)})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].score > all[j].score })
+
+	ids := make([]string, k)
+	for i := range ids {
+		ids[i] = all[i].id
+	}
+	return ids
+}
+
+func TestIndex_Recall(t *testing.T) {
+	vectors := randomVectors(1000, 32, 1)
+
+	ix, err := New("test", 32, Options{})
+	if err != nil {
+		t.Fatalf("New() returned unexpected error: %v", err)
+	}
+	for i, v := range vectors {
+		if err := ix.Insert(fmt.Sprint(i), "", v); err != nil {
+			t.Fatalf("Insert() returned unexpected error: %v", err)
+		}
+	}
+
+	const k = 10
+	found, total := 0, 0
+	for _, query := range randomVectors(50, 32, 2) {
+		results, err := ix.Search(query, k)
+		if err != nil {
+			t.Fatalf("Search() returned unexpected error: %v", err)
+		}
+		if len(results) != k {
+			t.Fatalf("Expected %d results, got %d", k, len(results))
+		}
+		for i := 1; i < len(results); i++ {
+			if results[i].Score > results[i-1].Score {
+				t.Fatalf("Expected results sorted by score, got %v", results)
+			}
+		}
+
+		expected := make(map[string]bool)
+		for _, id := range bruteForce(vectors, query, k) {
+			expected[id] = true
+		}
+		for _, r := range results {
+			if expected[r.ID] {
+				found++
+			}
+		}
+		total += k
+	}
+
+	if recall := float64(found) / float64(total); recall < 0.95 {
+		t.Errorf("Expected a recall of at least 0.95, got %.3f", recall)
+	}
+}
+
+func TestIndex_InsertDelete(t *testing.T) {
+	ix, err := New("test", 2, Options{Metric: Dot, M: 4})
+	if err != nil {
+		t.Fatalf("New() returned unexpected error: %v", err)
+	}
+
+	ix.Insert("east", "a", []float32{1, 0})
+	ix.Insert("north", "b", []float32{0, 1})
+	ix.Insert("far-east", "c", []float32{3, 0})
+
+	results, _ := ix.Search([]float32{1, 0.1}, 2)
+	if len(results) != 2 || results[0].ID != "far-east" || results[0].Score != 3 {
+		t.Errorf("Expected dot products to favour the longest vector, got %v", results)
+	}
+
+	if !ix.Delete("far-east") || ix.Delete("far-east") {
+		t.Error("Expected a vector to be deleted once")
+	}
+	results, _ = ix.Search([]float32{1, 0.1}, 3)
+	if len(results) != 2 || results[0].ID != "east" {
+		t.Errorf("Expected the deleted vector not to be returned, got %v", results)
+	}
+
+	// inserting an existing id replaces its vector
+	ix.Insert("north", "d", []float32{-1, 0})
+	if digest, ok := ix.Digest("north"); !ok || digest != "d" {
+		t.Errorf("Expected the digest of the replaced vector, got %s", digest)
+	}
+	results, _ = ix.Search([]float32{1, 0}, 1)
+	if results[0].ID != "east" || ix.Len() != 2 {
+		t.Errorf("Unexpected results after replacing a vector: %v", results)
+	}
+
+	ix.Delete("east")
+	ix.Insert("east", "a", []float32{1, 0})
+	if !ix.NeedsCompaction() {
+		t.Error("Expected the index to need compaction once deleted vectors outnumber the others")
+	}
+	ix.Compact()
+	if ix.NeedsCompaction() || len(ix.nodes) != 2 || !reflect.DeepEqual(ix.IDs(), []string{"east", "north"}) {
+		t.Errorf("Expected compaction to drop deleted vectors, got %d nodes", len(ix.nodes))
+	}
+
+	if err := ix.Insert("wrong", "", []float32{1, 2, 3}); err == nil {
+		t.Error("Expected an error for a vector of the wrong dimension")
+	}
+}
+
+func TestIndex_SaveLoad(t *testing.T) {
+	filePath := path.Join(t.TempDir(), "vectors.hnsw")
+
+	if _, err := Load(filePath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected a missing index to be reported as not existing, got %v", err)
+	}
+
+	ix, _ := New("hash-8", 8, Options{M: 8, EfSearch: 20})
+	vectors := randomVectors(200, 8, 3)
+	for i, v := range vectors {
+		ix.Insert(fmt.Sprint(i), fmt.Sprintf("digest-%d", i), v)
+	}
+	ix.Delete("7")
+
+	if err := ix.Save(filePath); err != nil {
+		t.Fatalf("Save() returned unexpected error: %v", err)
+	}
+
+	loaded, err := Load(filePath)
+	if err != nil {
+		t.Fatalf("Load() returned unexpected error: %v", err)
+	}
+
+	if loaded.Model() != "hash-8" || loaded.Dimension() != 8 || loaded.Options() != ix.Options() || loaded.Len() != 199 {
+		t.Errorf("Unexpected loaded index: %s, %d dimensions, %v, %d vectors", loaded.Model(), loaded.Dimension(), loaded.Options(), loaded.Len())
+	}
+
+	query := randomVectors(1, 8, 4)[0]
+	expected, _ := ix.Search(query, 5)
+	results, _ := loaded.Search(query, 5)
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("Expected the loaded index to return %v, got %v", expected, results)
+	}
+}
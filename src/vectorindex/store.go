@@ -0,0 +1,141 @@
+package vectorindex
+
+import (
+	"fmt"
+	"os"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "accretional.com/semantifly/proto/accretional.com/semantifly/proto"
+)
+
+// Save writes the index to a file, compacting it first if deleted vectors outnumber the others.
+// The file is replaced atomically so that a failed save never leaves a truncated index behind.
+func (ix *Index) Save(filePath string) error {
+	if ix.NeedsCompaction() {
+		ix.Compact()
+	}
+
+	data, err := proto.Marshal(ix.toProto())
+	if err != nil {
+		return fmt.Errorf("failed to marshal vector index: %w", err)
+	}
+
+	tmpPath := filePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write vector index: %w", err)
+	}
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		return fmt.Errorf("failed to replace vector index: %w", err)
+	}
+
+	return nil
+}
+
+// Load reads an index written by Save. The error wraps os.ErrNotExist if there is no such file.
+func Load(filePath string) (*Index, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vector index: %w", err)
+	}
+
+	stored := &pb.VectorIndex{}
+	if err := proto.Unmarshal(data, stored); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal vector index: %w", err)
+	}
+
+	return fromProto(stored)
+}
+
+func (ix *Index) toProto() *pb.VectorIndex {
+	stored := &pb.VectorIndex{
+		Model:          ix.model,
+		Dimension:      int32(ix.dimension),
+		Metric:         ix.options.Metric,
+		M:              int32(ix.options.M),
+		EfConstruction: int32(ix.options.EfConstruction),
+		EfSearch:       int32(ix.options.EfSearch),
+		EntryPoint:     ix.entryPoint,
+		MaxLevel:       int32(ix.maxLevel),
+		Nodes:          make([]*pb.VectorNode, len(ix.nodes)),
+	}
+
+	for i, n := range ix.nodes {
+		levels := make([]*pb.VectorNeighbors, len(n.neighbors))
+		for l, neighbors := range n.neighbors {
+			levels[l] = &pb.VectorNeighbors{Nodes: neighbors}
+		}
+		stored.Nodes[i] = &pb.VectorNode{
+			Id:      n.id,
+			Digest:  n.digest,
+			Vector:  n.vector,
+			Deleted: n.deleted,
+			Levels:  levels,
+		}
+	}
+
+	return stored
+}
+
+func fromProto(stored *pb.VectorIndex) (*Index, error) {
+	ix, err := New(stored.Model, int(stored.Dimension), Options{
+		Metric:         stored.Metric,
+		M:              int(stored.M),
+		EfConstruction: int(stored.EfConstruction),
+		EfSearch:       int(stored.EfSearch),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid vector index: %w", err)
+	}
+
+	if stored.EntryPoint >= int32(len(stored.Nodes)) {
+		return nil, fmt.Errorf("invalid vector index: entry point %d out of %d nodes", stored.EntryPoint, len(stored.Nodes))
+	}
+	ix.entryPoint = stored.EntryPoint
+	ix.maxLevel = int(stored.MaxLevel)
+
+	for i, sn := range stored.Nodes {
+		if len(sn.Vector) != ix.dimension {
+			return nil, fmt.Errorf("invalid vector index: node %s has %d dimensions, expected %d", sn.Id, len(sn.Vector), ix.dimension)
+		}
+
+		n := &node{
+			id:        sn.Id,
+			digest:    sn.Digest,
+			vector:    sn.Vector,
+			deleted:   sn.Deleted,
+			neighbors: make([][]int32, len(sn.Levels)),
+		}
+		for l, neighbors := range sn.Levels {
+			for _, neighbor := range neighbors.Nodes {
+				if neighbor < 0 || neighbor >= int32(len(stored.Nodes)) {
+					return nil, fmt.Errorf("invalid vector index: node %s links to %d out of %d nodes", sn.Id, neighbor, len(stored.Nodes))
+				}
+			}
+			n.neighbors[l] = neighbors.Nodes
+		}
+
+		ix.nodes = append(ix.nodes, n)
+		if n.deleted {
+			ix.deleted++
+		} else {
+			ix.ids[n.id] = int32(i)
+		}
+	}
+
+	// searches index the neighbours of a node by level, so links must not lead to levels a node is not on
+	for _, n := range ix.nodes {
+		for l, neighbors := range n.neighbors {
+			for _, neighbor := range neighbors {
+				if len(ix.nodes[neighbor].neighbors) <= l {
+					return nil, fmt.Errorf("invalid vector index: node %s links to %s on level %d", n.id, ix.nodes[neighbor].id, l)
+				}
+			}
+		}
+	}
+	if ix.entryPoint >= 0 && len(ix.nodes[ix.entryPoint].neighbors) != ix.maxLevel+1 {
+		return nil, fmt.Errorf("invalid vector index: entry point is not on level %d", ix.maxLevel)
+	}
+
+	return ix, nil
+}